@@ -0,0 +1,169 @@
+package pointer
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrOverflow is returned when an integer result does not fit its type.
+var ErrOverflow = errors.New("pointer: integer overflow")
+
+// AggregateOption configures the aggregation functions.
+type AggregateOption func(*aggregateConfig)
+
+type aggregateConfig struct {
+	nilAsZero bool
+	skipNaN   bool
+}
+
+// NilAsZero makes nil elements count as zero values instead of being
+// skipped.
+func NilAsZero() AggregateOption {
+	return func(c *aggregateConfig) {
+		c.nilAsZero = true
+	}
+}
+
+// SkipNaN makes NaN elements be skipped like nil ones. By default a NaN
+// propagates to the result of Sum, Min, Max, Mean and Quantile.
+func SkipNaN() AggregateOption {
+	return func(c *aggregateConfig) {
+		c.skipNaN = true
+	}
+}
+
+// aggregateValues calls fn with the value of every element of src that
+// takes part in an aggregation.
+func aggregateValues[T Number](src []*T, opts []AggregateOption, fn func(T)) {
+	var cfg aggregateConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	for _, p := range src {
+		switch {
+		case p == nil:
+			if cfg.nilAsZero {
+				fn(0)
+			}
+		case cfg.skipNaN && *p != *p:
+		default:
+			fn(*p)
+		}
+	}
+}
+
+// Count returns the number of non-nil elements of src.
+func Count[T any](src []*T) int {
+	n := 0
+	for _, p := range src {
+		if p != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Sum returns the sum of the non-nil elements of src, or 0 if there are
+// none. Integer sums wrap around on overflow; see CheckedSum.
+func Sum[T Number](src []*T, opts ...AggregateOption) T {
+	var sum T
+	aggregateValues(src, opts, func(v T) {
+		sum += v
+	})
+	return sum
+}
+
+// CheckedSum returns the sum of the non-nil elements of src, or
+// ErrOverflow if it does not fit in T.
+func CheckedSum[T Integer](src []*T) (T, error) {
+	var sum T
+	for _, p := range src {
+		if p == nil {
+			continue
+		}
+		s := sum + *p
+		if (*p > 0 && s < sum) || (*p < 0 && s > sum) {
+			return 0, ErrOverflow
+		}
+		sum = s
+	}
+	return sum, nil
+}
+
+// Min returns the smallest non-nil element of src. ok is false if there
+// are no elements to compare.
+func Min[T Number](src []*T, opts ...AggregateOption) (T, bool) {
+	var m T
+	ok := false
+	aggregateValues(src, opts, func(v T) {
+		if !ok || v < m || v != v {
+			if m == m {
+				m = v
+			}
+			ok = true
+		}
+	})
+	return m, ok
+}
+
+// Max returns the largest non-nil element of src. ok is false if there
+// are no elements to compare.
+func Max[T Number](src []*T, opts ...AggregateOption) (T, bool) {
+	var m T
+	ok := false
+	aggregateValues(src, opts, func(v T) {
+		if !ok || v > m || v != v {
+			if m == m {
+				m = v
+			}
+			ok = true
+		}
+	})
+	return m, ok
+}
+
+// Mean returns the arithmetic mean of the non-nil elements of src. ok is
+// false if there are none.
+func Mean[T Number](src []*T, opts ...AggregateOption) (float64, bool) {
+	var sum float64
+	n := 0
+	aggregateValues(src, opts, func(v T) {
+		sum += float64(v)
+		n++
+	})
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
+
+// Quantile returns the q-quantile, 0 <= q <= 1, of the non-nil elements
+// of src, interpolating linearly between the closest ranks. ok is false if
+// q is out of range or there are no elements.
+func Quantile[T Number](src []*T, q float64, opts ...AggregateOption) (float64, bool) {
+	if q < 0 || q > 1 || q != q {
+		return 0, false
+	}
+	var vals []float64
+	nan := false
+	aggregateValues(src, opts, func(v T) {
+		if v != v {
+			nan = true
+		}
+		vals = append(vals, float64(v))
+	})
+	switch {
+	case len(vals) == 0:
+		return 0, false
+	case nan:
+		return math.NaN(), true
+	}
+	sort.Float64s(vals)
+	pos := q * float64(len(vals)-1)
+	lo := int(math.Floor(pos))
+	if lo == len(vals)-1 {
+		return vals[lo], true
+	}
+	return vals[lo] + (pos-float64(lo))*(vals[lo+1]-vals[lo]), true
+}
@@ -0,0 +1,113 @@
+package pointer
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAggregateInt64(t *testing.T) {
+	src := []*int64{Int64P(4), nil, Int64P(-2), Int64P(10), nil}
+	if e, a := 3, Count(src); e != a {
+		t.Errorf("Unexpected count %d", a)
+	}
+	if e, a := int64(12), Sum(src); e != a {
+		t.Errorf("Unexpected sum %d", a)
+	}
+	if v, ok := Min(src); !ok || v != -2 {
+		t.Errorf("Unexpected min %d", v)
+	}
+	if v, ok := Max(src); !ok || v != 10 {
+		t.Errorf("Unexpected max %d", v)
+	}
+	if v, ok := Mean(src); !ok || v != 4 {
+		t.Errorf("Unexpected mean %v", v)
+	}
+	if v, ok := Mean(src, NilAsZero()); !ok || v != 2.4 {
+		t.Errorf("Unexpected mean %v", v)
+	}
+	if v, ok := Quantile(src, 0.5); !ok || v != 4 {
+		t.Errorf("Unexpected median %v", v)
+	}
+	if v, ok := Quantile(src, 0.25, NilAsZero()); !ok || v != 0 {
+		t.Errorf("Unexpected quantile %v", v)
+	}
+	if v, ok := Quantile(src, 0.75); !ok || v != 7 {
+		t.Errorf("Unexpected quantile %v", v)
+	}
+	if v, ok := Quantile(src, 1); !ok || v != 10 {
+		t.Errorf("Unexpected quantile %v", v)
+	}
+	if _, ok := Quantile(src, 1.5); ok {
+		t.Errorf("Expected out of range quantile to fail")
+	}
+}
+
+func TestAggregateEmpty(t *testing.T) {
+	src := []*uint8{nil, nil}
+	if a := Sum(src); a != 0 {
+		t.Errorf("Unexpected sum %d", a)
+	}
+	if _, ok := Min(src); ok {
+		t.Errorf("Expected min of nils to fail")
+	}
+	if _, ok := Max[uint8](nil); ok {
+		t.Errorf("Expected max of empty slice to fail")
+	}
+	if _, ok := Mean(src); ok {
+		t.Errorf("Expected mean of nils to fail")
+	}
+	if _, ok := Quantile(src, 0.5); ok {
+		t.Errorf("Expected quantile of nils to fail")
+	}
+	if v, ok := Min(src, NilAsZero()); !ok || v != 0 {
+		t.Errorf("Unexpected min %d", v)
+	}
+}
+
+func TestAggregateNaN(t *testing.T) {
+	src := []*float64{Float64P(1.5), Float64P(math.NaN()), nil, Float64P(-0.5)}
+	if a := Sum(src); !math.IsNaN(a) {
+		t.Errorf("Unexpected sum %v", a)
+	}
+	if a := Sum(src, SkipNaN()); a != 1 {
+		t.Errorf("Unexpected sum %v", a)
+	}
+	if a, _ := Min(src); !math.IsNaN(a) {
+		t.Errorf("Unexpected min %v", a)
+	}
+	if a, _ := Max(src); !math.IsNaN(a) {
+		t.Errorf("Unexpected max %v", a)
+	}
+	if a, _ := Max(src, SkipNaN()); a != 1.5 {
+		t.Errorf("Unexpected max %v", a)
+	}
+	if a, _ := Mean(src, SkipNaN(), NilAsZero()); a != 1.0/3 {
+		t.Errorf("Unexpected mean %v", a)
+	}
+	if a, ok := Quantile(src, 0.5); !ok || !math.IsNaN(a) {
+		t.Errorf("Unexpected quantile %v", a)
+	}
+	if a, _ := Quantile([]*float32{Float32P(2), Float32P(float32(math.NaN()))}, 0.5, SkipNaN()); a != 2 {
+		t.Errorf("Unexpected quantile %v", a)
+	}
+}
+
+func TestCheckedSum(t *testing.T) {
+	if v, err := CheckedSum([]*int8{Int8P(100), nil, Int8P(27)}); err != nil || v != 127 {
+		t.Errorf("Unexpected sum %v %v", v, err)
+	}
+	if _, err := CheckedSum([]*int8{Int8P(100), Int8P(28)}); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected overflow, got %v", err)
+	}
+	if _, err := CheckedSum([]*int8{Int8P(-100), Int8P(-29)}); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected overflow, got %v", err)
+	}
+	if _, err := CheckedSum([]*uint64{Uint64P(math.MaxUint64), Uint64P(1)}); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected overflow, got %v", err)
+	}
+	if v, err := CheckedSum([]*time.Duration{DurationP(time.Second), DurationP(time.Minute)}); err != nil || v != 61*time.Second {
+		t.Errorf("Unexpected sum %v %v", v, err)
+	}
+}
@@ -0,0 +1,365 @@
+package pointer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"time"
+)
+
+// binaryVersion is the format version written by BinarySlice.
+const binaryVersion = 1
+
+// ErrBinaryFormat is returned, wrapped, for binary data that is
+// truncated, malformed or of another version or type.
+var ErrBinaryFormat = errors.New("pointer: invalid binary data")
+
+// Type codes identify the element type of an encoded slice.
+const (
+	binaryBool byte = iota + 1
+	binaryString
+	binaryInt
+	binaryInt8
+	binaryInt16
+	binaryInt32
+	binaryInt64
+	binaryUint
+	binaryUint8
+	binaryUint16
+	binaryUint32
+	binaryUint64
+	binaryFloat32
+	binaryFloat64
+	binaryDuration
+	binaryTime
+)
+
+// BinarySlice is a slice of optional values with a compact binary
+// encoding: a format version byte, a type code, the length as a uvarint,
+// a validity bitmap with one bit per element, set for non-nil elements,
+// and then the non-nil values packed in order. Fixed-size values are
+// little endian; strings and times are prefixed with their length as a
+// uvarint. Since it implements encoding.BinaryMarshaler, gob encodes a
+// BinarySlice in this form too, preserving nil elements.
+type BinarySlice[T Binary] []*T
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s BinarySlice[T]) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(nil)
+}
+
+// AppendBinary appends the encoding of s to b.
+func (s BinarySlice[T]) AppendBinary(b []byte) ([]byte, error) {
+	c := binaryCodecOf[T]()
+	b = append(b, binaryVersion, c.code)
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	start := len(b)
+	b = append(b, make([]byte, (len(s)+7)/8)...)
+	for i, p := range s {
+		if p != nil {
+			b[start+i/8] |= 1 << (i % 8)
+		}
+	}
+	for i, p := range s {
+		if p != nil {
+			var err error
+			if b, err = c.put(b, *p); err != nil {
+				return nil, fmt.Errorf("pointer: element %d: %w", i, err)
+			}
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the
+// contents of s, and fails on any trailing data.
+func (s *BinarySlice[T]) UnmarshalBinary(data []byte) error {
+	out, n, err := decodeBinarySlice[T](data)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return fmt.Errorf("%w: %d trailing bytes", ErrBinaryFormat, len(data)-n)
+	}
+	*s = out
+	return nil
+}
+
+func decodeBinarySlice[T Binary](data []byte) (BinarySlice[T], int, error) {
+	c := binaryCodecOf[T]()
+	if len(data) < 2 {
+		return nil, 0, fmt.Errorf("%w: short header", ErrBinaryFormat)
+	}
+	if data[0] != binaryVersion {
+		return nil, 0, fmt.Errorf("%w: unsupported version %d", ErrBinaryFormat, data[0])
+	}
+	if data[1] != c.code {
+		return nil, 0, fmt.Errorf("%w: type code %d, expected %d", ErrBinaryFormat, data[1], c.code)
+	}
+	length, k := uvarint(data[2:])
+	if k <= 0 {
+		return nil, 0, fmt.Errorf("%w: bad length", ErrBinaryFormat)
+	}
+	off := 2 + k
+	if length > uint64(len(data)-off)*8 {
+		return nil, 0, fmt.Errorf("%w: length %d exceeds data", ErrBinaryFormat, length)
+	}
+	n := int(length)
+	bitmap := data[off : off+(n+7)/8]
+	off += len(bitmap)
+	if n%8 != 0 && bitmap[len(bitmap)-1]>>(n%8) != 0 {
+		return nil, 0, fmt.Errorf("%w: bits set past length", ErrBinaryFormat)
+	}
+	valid := 0
+	for _, m := range bitmap {
+		valid += bits.OnesCount8(m)
+	}
+	if c.size > 0 && valid*c.size > len(data)-off {
+		return nil, 0, fmt.Errorf("%w: truncated values", ErrBinaryFormat)
+	}
+
+	out := make(BinarySlice[T], n)
+	vals := make([]T, valid)
+	j := 0
+	for i := range out {
+		if bitmap[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		v, k, err := c.get(data[off:])
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: element %d: %v", ErrBinaryFormat, i, err)
+		}
+		off += k
+		vals[j] = v
+		out[i] = &vals[j]
+		j++
+	}
+	return out, off, nil
+}
+
+// BinaryEncoder writes a stream of slices in the BinarySlice format, each
+// preceded by its size as a uvarint, so that a large column can be
+// written in chunks.
+type BinaryEncoder[T Binary] struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewBinaryEncoder returns a BinaryEncoder that writes to w.
+func NewBinaryEncoder[T Binary](w io.Writer) *BinaryEncoder[T] {
+	return &BinaryEncoder[T]{w: w}
+}
+
+// Encode writes src as the next slice of the stream.
+func (e *BinaryEncoder[T]) Encode(src []*T) error {
+	body, err := BinarySlice[T](src).AppendBinary(e.buf[:0])
+	if err != nil {
+		return err
+	}
+	e.buf = body
+	var size [binary.MaxVarintLen64]byte
+	if _, err := e.w.Write(size[:binary.PutUvarint(size[:], uint64(len(body)))]); err != nil {
+		return err
+	}
+	_, err = e.w.Write(body)
+	return err
+}
+
+// BinaryDecoder reads a stream of slices written by a BinaryEncoder.
+type BinaryDecoder[T Binary] struct {
+	r   *bufio.Reader
+	buf bytes.Buffer
+}
+
+// NewBinaryDecoder returns a BinaryDecoder that reads from r.
+func NewBinaryDecoder[T Binary](r io.Reader) *BinaryDecoder[T] {
+	return &BinaryDecoder[T]{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next slice of the stream. It returns io.EOF at the end
+// of the stream, and io.ErrUnexpectedEOF within a truncated slice.
+func (d *BinaryDecoder[T]) Decode() ([]*T, error) {
+	size, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, err
+	}
+	d.buf.Reset()
+	// CopyN grows the buffer as data arrives rather than trusting size.
+	if _, err := io.CopyN(&d.buf, d.r, int64(min(size, math.MaxInt64))); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	var s BinarySlice[T]
+	if err := s.UnmarshalBinary(d.buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+type binaryCodec[T any] struct {
+	code byte
+	size int // width of every value, or 0 if values vary in size
+	put  func(b []byte, v T) ([]byte, error)
+	get  func(b []byte) (T, int, error)
+}
+
+var errShortValue = errors.New("short value")
+
+// fixedCodec returns a binaryCodec for values that are always size bytes.
+func fixedCodec[T any](code byte, size int, put func([]byte, T) []byte, get func([]byte) T) *binaryCodec[T] {
+	return &binaryCodec[T]{
+		code: code,
+		size: size,
+		put: func(b []byte, v T) ([]byte, error) {
+			return put(b, v), nil
+		},
+		get: func(b []byte) (T, int, error) {
+			if len(b) < size {
+				var zero T
+				return zero, 0, errShortValue
+			}
+			return get(b), size, nil
+		},
+	}
+}
+
+// getBytes reads a value prefixed by its length as a uvarint.
+func getBytes(b []byte) ([]byte, int, error) {
+	n, k := uvarint(b)
+	if k <= 0 || n > uint64(len(b)-k) {
+		return nil, 0, errShortValue
+	}
+	return b[k : k+int(n)], k + int(n), nil
+}
+
+// uvarint is binary.Uvarint, but also rejects overlong encodings, so
+// that every value has a single encoding.
+func uvarint(b []byte) (uint64, int) {
+	v, k := binary.Uvarint(b)
+	if k > 1 && b[k-1] == 0 {
+		return 0, -k
+	}
+	return v, k
+}
+
+var littleEndian = binary.LittleEndian
+
+// binaryCodecOf returns the codec of T.
+func binaryCodecOf[T Binary]() *binaryCodec[T] {
+	var c any
+	switch any(*new(T)).(type) {
+	case bool:
+		c = &binaryCodec[bool]{
+			code: binaryBool,
+			size: 1,
+			put: func(b []byte, v bool) ([]byte, error) {
+				if v {
+					return append(b, 1), nil
+				}
+				return append(b, 0), nil
+			},
+			get: func(b []byte) (bool, int, error) {
+				if len(b) < 1 {
+					return false, 0, errShortValue
+				}
+				if b[0] > 1 {
+					return false, 0, fmt.Errorf("bad bool %d", b[0])
+				}
+				return b[0] == 1, 1, nil
+			},
+		}
+	case string:
+		c = &binaryCodec[string]{
+			code: binaryString,
+			put: func(b []byte, v string) ([]byte, error) {
+				return append(binary.AppendUvarint(b, uint64(len(v))), v...), nil
+			},
+			get: func(b []byte) (string, int, error) {
+				s, k, err := getBytes(b)
+				return string(s), k, err
+			},
+		}
+	case int:
+		c = fixedCodec(binaryInt, 8,
+			func(b []byte, v int) []byte { return littleEndian.AppendUint64(b, uint64(v)) },
+			func(b []byte) int { return int(littleEndian.Uint64(b)) })
+	case int8:
+		c = fixedCodec(binaryInt8, 1,
+			func(b []byte, v int8) []byte { return append(b, byte(v)) },
+			func(b []byte) int8 { return int8(b[0]) })
+	case int16:
+		c = fixedCodec(binaryInt16, 2,
+			func(b []byte, v int16) []byte { return littleEndian.AppendUint16(b, uint16(v)) },
+			func(b []byte) int16 { return int16(littleEndian.Uint16(b)) })
+	case int32:
+		c = fixedCodec(binaryInt32, 4,
+			func(b []byte, v int32) []byte { return littleEndian.AppendUint32(b, uint32(v)) },
+			func(b []byte) int32 { return int32(littleEndian.Uint32(b)) })
+	case int64:
+		c = fixedCodec(binaryInt64, 8,
+			func(b []byte, v int64) []byte { return littleEndian.AppendUint64(b, uint64(v)) },
+			func(b []byte) int64 { return int64(littleEndian.Uint64(b)) })
+	case uint:
+		c = fixedCodec(binaryUint, 8,
+			func(b []byte, v uint) []byte { return littleEndian.AppendUint64(b, uint64(v)) },
+			func(b []byte) uint { return uint(littleEndian.Uint64(b)) })
+	case uint8:
+		c = fixedCodec(binaryUint8, 1,
+			func(b []byte, v uint8) []byte { return append(b, v) },
+			func(b []byte) uint8 { return b[0] })
+	case uint16:
+		c = fixedCodec(binaryUint16, 2,
+			func(b []byte, v uint16) []byte { return littleEndian.AppendUint16(b, v) },
+			func(b []byte) uint16 { return littleEndian.Uint16(b) })
+	case uint32:
+		c = fixedCodec(binaryUint32, 4,
+			func(b []byte, v uint32) []byte { return littleEndian.AppendUint32(b, v) },
+			func(b []byte) uint32 { return littleEndian.Uint32(b) })
+	case uint64:
+		c = fixedCodec(binaryUint64, 8,
+			func(b []byte, v uint64) []byte { return littleEndian.AppendUint64(b, v) },
+			func(b []byte) uint64 { return littleEndian.Uint64(b) })
+	case float32:
+		c = fixedCodec(binaryFloat32, 4,
+			func(b []byte, v float32) []byte { return littleEndian.AppendUint32(b, math.Float32bits(v)) },
+			func(b []byte) float32 { return math.Float32frombits(littleEndian.Uint32(b)) })
+	case float64:
+		c = fixedCodec(binaryFloat64, 8,
+			func(b []byte, v float64) []byte { return littleEndian.AppendUint64(b, math.Float64bits(v)) },
+			func(b []byte) float64 { return math.Float64frombits(littleEndian.Uint64(b)) })
+	case time.Duration:
+		c = fixedCodec(binaryDuration, 8,
+			func(b []byte, v time.Duration) []byte { return littleEndian.AppendUint64(b, uint64(v)) },
+			func(b []byte) time.Duration { return time.Duration(littleEndian.Uint64(b)) })
+	case time.Time:
+		c = &binaryCodec[time.Time]{
+			code: binaryTime,
+			put: func(b []byte, v time.Time) ([]byte, error) {
+				tb, err := v.MarshalBinary()
+				if err != nil {
+					return b, err
+				}
+				return append(binary.AppendUvarint(b, uint64(len(tb))), tb...), nil
+			},
+			get: func(b []byte) (time.Time, int, error) {
+				tb, k, err := getBytes(b)
+				if err != nil {
+					return time.Time{}, 0, err
+				}
+				var t time.Time
+				if err := t.UnmarshalBinary(tb); err != nil {
+					return t, 0, err
+				}
+				return t, k, nil
+			},
+		}
+	}
+	return c.(*binaryCodec[T])
+}
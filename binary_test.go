@@ -0,0 +1,190 @@
+package pointer
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBinarySliceRoundTrip(t *testing.T) {
+	when := time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC)
+	cases := []any{
+		BinarySlice[bool]{BoolP(true), nil, BoolP(false)},
+		BinarySlice[string]{StringP("a"), nil, StringP(""), StringP("héllo")},
+		BinarySlice[int]{IntP(-1), IntP(math.MaxInt)},
+		BinarySlice[int8]{Int8P(-128), nil},
+		BinarySlice[int16]{nil, Int16P(300)},
+		BinarySlice[int32]{Int32P(math.MinInt32)},
+		BinarySlice[int64]{nil, nil, nil, nil, nil, nil, nil, nil, Int64P(9)},
+		BinarySlice[uint]{UintP(7)},
+		BinarySlice[uint8]{Uint8P(255)},
+		BinarySlice[uint16]{Uint16P(65535)},
+		BinarySlice[uint32]{Uint32P(1 << 31)},
+		BinarySlice[uint64]{Uint64P(math.MaxUint64), nil},
+		BinarySlice[float32]{Float32P(1.5), nil},
+		BinarySlice[float64]{Float64P(math.Inf(-1)), nil, Float64P(0.1)},
+		BinarySlice[time.Duration]{DurationP(-time.Hour)},
+		BinarySlice[time.Time]{&when, nil},
+		BinarySlice[int64]{},
+	}
+	for idx, c := range cases {
+		m := reflect.ValueOf(c).MethodByName("MarshalBinary").Call(nil)
+		if err, _ := m[1].Interface().(error); err != nil {
+			t.Fatalf("Unexpected error %v at idx %d", err, idx)
+		}
+		out := reflect.New(reflect.TypeOf(c))
+		res := out.MethodByName("UnmarshalBinary").Call([]reflect.Value{m[0]})
+		if err, _ := res[0].Interface().(error); err != nil {
+			t.Fatalf("Unexpected error %v at idx %d", err, idx)
+		}
+		if !reflect.DeepEqual(c, out.Elem().Interface()) {
+			t.Errorf("Unexpected round trip at idx %d: %v", idx, out.Elem().Interface())
+		}
+	}
+}
+
+func TestBinarySliceLayout(t *testing.T) {
+	b, _ := BinarySlice[int16]{Int16P(1), nil, Int16P(-2)}.MarshalBinary()
+	expected := []byte{binaryVersion, binaryInt16, 3, 0b101, 1, 0, 0xfe, 0xff}
+	if !bytes.Equal(expected, b) {
+		t.Errorf("Unexpected encoding %v", b)
+	}
+}
+
+func TestBinarySliceMalformed(t *testing.T) {
+	cases := [][]byte{
+		nil,
+		{binaryVersion},
+		{2, binaryInt16, 0},
+		{binaryVersion, binaryInt32, 0},
+		{binaryVersion, binaryInt16},
+		{binaryVersion, binaryInt16, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01},
+		{binaryVersion, binaryInt16, 3, 0b1101, 1, 0, 2, 0},
+		{binaryVersion, binaryInt16, 1, 1, 1},
+		{binaryVersion, binaryInt16, 1, 0, 0},
+		{binaryVersion, binaryInt16, 0x80, 0},
+	}
+	for idx, c := range cases {
+		var s BinarySlice[int16]
+		if err := s.UnmarshalBinary(c); !errors.Is(err, ErrBinaryFormat) {
+			t.Errorf("Expected ErrBinaryFormat at idx %d, got %v", idx, err)
+		}
+	}
+	var s BinarySlice[string]
+	if err := s.UnmarshalBinary([]byte{binaryVersion, binaryString, 1, 1, 5, 'a'}); !errors.Is(err, ErrBinaryFormat) {
+		t.Errorf("Expected ErrBinaryFormat, got %v", err)
+	}
+	var bs BinarySlice[bool]
+	if err := bs.UnmarshalBinary([]byte{binaryVersion, binaryBool, 1, 1, 2}); !errors.Is(err, ErrBinaryFormat) {
+		t.Errorf("Expected ErrBinaryFormat, got %v", err)
+	}
+}
+
+func TestBinarySliceGob(t *testing.T) {
+	type cache struct {
+		Scores BinarySlice[float64]
+	}
+	in := cache{Scores: BinarySlice[float64]{nil, Float64P(2), nil}}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	var out cache
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("Unexpected value %v", out.Scores)
+	}
+}
+
+func TestBinaryEncoder(t *testing.T) {
+	chunks := [][]*string{{StringP("a"), nil}, {}, {nil, StringP("b")}}
+	var buf bytes.Buffer
+	enc := NewBinaryEncoder[string](&buf)
+	for _, c := range chunks {
+		if err := enc.Encode(c); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+	}
+	data := buf.Bytes()
+
+	dec := NewBinaryDecoder[string](bytes.NewReader(data))
+	for idx, c := range chunks {
+		out, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Unexpected error %v at idx %d", err, idx)
+		}
+		if !reflect.DeepEqual(c, out) {
+			t.Errorf("Unexpected value at idx %d: %v", idx, out)
+		}
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+
+	dec = NewBinaryDecoder[string](bytes.NewReader(data[:len(data)-1]))
+	dec.Decode()
+	dec.Decode()
+	if _, err := dec.Decode(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected ErrUnexpectedEOF, got %v", err)
+	}
+	dec = NewBinaryDecoder[string](bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0x0f, 1}))
+	if _, err := dec.Decode(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected ErrUnexpectedEOF, got %v", err)
+	}
+	if _, err := NewBinaryDecoder[int64](bytes.NewReader(data)).Decode(); !errors.Is(err, ErrBinaryFormat) {
+		t.Errorf("Expected ErrBinaryFormat, got %v", err)
+	}
+}
+
+// fuzzBinarySlice checks that decoding data never panics, and that data
+// that decodes is the canonical encoding of the result.
+func fuzzBinarySlice[T Binary](t *testing.T, data []byte, canonical bool) {
+	var s BinarySlice[T]
+	if err := s.UnmarshalBinary(data); err != nil {
+		if !errors.Is(err, ErrBinaryFormat) {
+			t.Errorf("Unexpected error %v", err)
+		}
+		return
+	}
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if canonical && !bytes.Equal(data, b) {
+		t.Errorf("Unexpected re-encoding %v of %v", b, data)
+	}
+}
+
+func FuzzBinarySliceInt64(f *testing.F) {
+	b, _ := BinarySlice[int64]{Int64P(1), nil, Int64P(-1)}.MarshalBinary()
+	f.Add(b)
+	f.Add([]byte{binaryVersion, binaryInt64, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzBinarySlice[int64](t, data, true)
+	})
+}
+
+func FuzzBinarySliceString(f *testing.F) {
+	b, _ := BinarySlice[string]{StringP("a"), nil, StringP("")}.MarshalBinary()
+	f.Add(b)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzBinarySlice[string](t, data, true)
+	})
+}
+
+func FuzzBinarySliceTime(f *testing.F) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC)
+	b, _ := BinarySlice[time.Time]{&now, nil}.MarshalBinary()
+	f.Add(b)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// time.Time accepts several encodings of one instant.
+		fuzzBinarySlice[time.Time](t, data, false)
+	})
+}
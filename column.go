@@ -0,0 +1,192 @@
+package pointer
+
+import "math/bits"
+
+// Column is a nullable vector: the values of a []*T stored densely in a
+// []T, with a validity bitset marking the non-nil elements. Nil elements
+// hold the zero value. Column trades the pointer per element of a []*T
+// for one bit, and lets kernels such as SumColumn scan the values
+// without chasing pointers. The zero Column is empty and ready to use.
+type Column[T any] struct {
+	values []T
+	valid  []uint64
+	nulls  int
+}
+
+// NewColumn returns a Column holding the elements of src.
+func NewColumn[T any](src []*T) *Column[T] {
+	c := &Column[T]{
+		values: make([]T, len(src)),
+		valid:  make([]uint64, (len(src)+63)/64),
+	}
+	for i, p := range src {
+		if p == nil {
+			c.nulls++
+			continue
+		}
+		c.values[i] = *p
+		c.valid[i/64] |= 1 << (i % 64)
+	}
+	return c
+}
+
+// Len returns the number of elements of c, nil or not.
+func (c *Column[T]) Len() int {
+	return len(c.values)
+}
+
+// NullCount returns the number of nil elements of c.
+func (c *Column[T]) NullCount() int {
+	return c.nulls
+}
+
+// Valid reports whether element i of c is non-nil.
+func (c *Column[T]) Valid(i int) bool {
+	_ = c.values[i] // bounds check
+	return c.valid[i/64]&(1<<(i%64)) != 0
+}
+
+// Get returns element i of c. ok is false if the element is nil.
+func (c *Column[T]) Get(i int) (v T, ok bool) {
+	return c.values[i], c.Valid(i)
+}
+
+// Values returns the dense values of c, with the zero value at nil
+// elements. The slice is shared with c and must not be modified.
+func (c *Column[T]) Values() []T {
+	return c.values[:len(c.values):len(c.values)]
+}
+
+// ToPtrSlice returns the elements of c as a []*T, with nil elements as
+// nil. The pointers refer to a copy of the values of c.
+func (c *Column[T]) ToPtrSlice() []*T {
+	values := append([]T(nil), c.values...)
+	out := make([]*T, len(values))
+	for i := range values {
+		if c.valid[i/64]&(1<<(i%64)) != 0 {
+			out[i] = &values[i]
+		}
+	}
+	return out
+}
+
+// Append appends the value of p, or a nil element if p is nil, to c.
+func (c *Column[T]) Append(p *T) {
+	i := len(c.values)
+	if i%64 == 0 {
+		c.valid = append(c.valid, 0)
+	}
+	if p == nil {
+		var zero T
+		c.values = append(c.values, zero)
+		c.nulls++
+		return
+	}
+	c.values = append(c.values, *p)
+	c.valid[i/64] |= 1 << (i % 64)
+}
+
+// Range calls fn for every element of c in order, with ok false for nil
+// elements, until fn returns false.
+func (c *Column[T]) Range(fn func(i int, v T, ok bool) bool) {
+	for i, v := range c.values {
+		if !fn(i, v, c.valid[i/64]&(1<<(i%64)) != 0) {
+			return
+		}
+	}
+}
+
+// Slice returns a new Column holding elements i through j-1 of c. It
+// panics if the bounds are out of range, like a slice expression.
+func (c *Column[T]) Slice(i, j int) *Column[T] {
+	values := c.values[i:j]
+	out := &Column[T]{
+		values: append([]T(nil), values...),
+		valid:  make([]uint64, (len(values)+63)/64),
+	}
+	for k := range values {
+		if c.valid[(i+k)/64]&(1<<((i+k)%64)) != 0 {
+			out.valid[k/64] |= 1 << (k % 64)
+		} else {
+			out.nulls++
+		}
+	}
+	return out
+}
+
+// Filter returns a new Column holding the elements of c for which keep
+// returns true. keep is called with ok false for nil elements.
+func (c *Column[T]) Filter(keep func(v T, ok bool) bool) *Column[T] {
+	out := &Column[T]{}
+	for i, v := range c.values {
+		ok := c.valid[i/64]&(1<<(i%64)) != 0
+		if !keep(v, ok) {
+			continue
+		}
+		if ok {
+			out.Append(&v)
+		} else {
+			out.Append(nil)
+		}
+	}
+	return out
+}
+
+// SumColumn returns the sum of the non-nil elements of c, or 0 if there
+// are none. Integer sums wrap around on overflow, as for Sum.
+func SumColumn[T Number](c *Column[T]) T {
+	// Nil elements hold zero, so they need not be skipped.
+	var sum T
+	for _, v := range c.values {
+		sum += v
+	}
+	return sum
+}
+
+// MinColumn returns the smallest non-nil element of c. ok is false if
+// there are none. A NaN propagates to the result, as for Min.
+func MinColumn[T Number](c *Column[T]) (T, bool) {
+	return extremeColumn(c, false)
+}
+
+// MaxColumn returns the largest non-nil element of c. ok is false if
+// there are none. A NaN propagates to the result, as for Max.
+func MaxColumn[T Number](c *Column[T]) (T, bool) {
+	return extremeColumn(c, true)
+}
+
+// extremeColumn returns the largest non-nil element of c if max is set,
+// or else the smallest. Fully valid words of the bitset are scanned
+// without testing each bit.
+func extremeColumn[T Number](c *Column[T], max bool) (T, bool) {
+	var m T
+	ok := false
+	for w, word := range c.valid {
+		base := w * 64
+		if word == ^uint64(0) {
+			vals := c.values[base : base+64]
+			if !ok {
+				m, ok = vals[0], true
+			}
+			for _, v := range vals {
+				if v != v {
+					return v, true
+				}
+				if (max && v > m) || (!max && v < m) {
+					m = v
+				}
+			}
+			continue
+		}
+		for ; word != 0; word &= word - 1 {
+			v := c.values[base+bits.TrailingZeros64(word)]
+			if v != v {
+				return v, true
+			}
+			if !ok || (max && v > m) || (!max && v < m) {
+				m, ok = v, true
+			}
+		}
+	}
+	return m, ok
+}
@@ -0,0 +1,189 @@
+package pointer
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestColumn(t *testing.T) {
+	src := make([]*int, 130)
+	for i := range src {
+		if i%3 != 0 {
+			src[i] = IntP(i)
+		}
+	}
+	c := NewColumn(src)
+	if e, a := 130, c.Len(); e != a {
+		t.Errorf("Unexpected length %d", a)
+	}
+	if e, a := 44, c.NullCount(); e != a {
+		t.Errorf("Unexpected null count %d", a)
+	}
+	if v, ok := c.Get(4); v != 4 || !ok {
+		t.Errorf("Unexpected value %d %v", v, ok)
+	}
+	if v, ok := c.Get(129); v != 0 || ok {
+		t.Errorf("Unexpected value %d %v", v, ok)
+	}
+	if e, a := 128, c.Values()[128]; e != a {
+		t.Errorf("Unexpected value %d", a)
+	}
+	out := c.ToPtrSlice()
+	if !reflect.DeepEqual(src, out) {
+		t.Errorf("Unexpected round trip %v", out)
+	}
+	*out[1] = -1
+	if v, _ := c.Get(1); v != 1 {
+		t.Errorf("Unexpected aliasing %d", v)
+	}
+
+	var appended Column[int]
+	for _, p := range src {
+		appended.Append(p)
+	}
+	if !reflect.DeepEqual(c, &appended) {
+		t.Errorf("Unexpected appended column")
+	}
+
+	s := c.Slice(62, 68)
+	if e, a := []*int{IntP(62), nil, IntP(64), IntP(65), nil, IntP(67)}, s.ToPtrSlice(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected slice %v", a)
+	}
+	if e, a := 2, s.NullCount(); e != a {
+		t.Errorf("Unexpected null count %d", a)
+	}
+
+	f := c.Filter(func(v int, ok bool) bool { return !ok || v%2 == 0 })
+	if e, a := 87, f.Len(); e != a {
+		t.Errorf("Unexpected length %d", a)
+	}
+	if v, ok := f.Get(1); v != 2 || !ok {
+		t.Errorf("Unexpected value %d %v", v, ok)
+	}
+
+	n := 0
+	c.Range(func(i int, v int, ok bool) bool {
+		if ok != (src[i] != nil) || (ok && v != *src[i]) {
+			t.Errorf("Unexpected element %d: %d %v", i, v, ok)
+		}
+		n++
+		return i < 9
+	})
+	if n != 10 {
+		t.Errorf("Unexpected visit count %d", n)
+	}
+}
+
+func TestColumnKernels(t *testing.T) {
+	cases := [][]*float64{
+		nil,
+		{nil, nil},
+		{Float64P(2), nil, Float64P(-1), Float64P(5)},
+		{Float64P(1), Float64P(math.NaN()), nil},
+		Float64PSlice(make([]float64, 64)),
+	}
+	for idx, src := range cases {
+		c := NewColumn(src)
+		if e, a := Sum(src), SumColumn(c); e != a && !(e != e && a != a) {
+			t.Errorf("Unexpected sum at idx %d: %v", idx, a)
+		}
+		em, eok := Min(src)
+		am, aok := MinColumn(c)
+		if eok != aok || (em != am && !(em != em && am != am)) {
+			t.Errorf("Unexpected min at idx %d: %v %v", idx, am, aok)
+		}
+		em, eok = Max(src)
+		am, aok = MaxColumn(c)
+		if eok != aok || (em != am && !(em != em && am != am)) {
+			t.Errorf("Unexpected max at idx %d: %v %v", idx, am, aok)
+		}
+	}
+
+	src := make([]*int64, 200)
+	for i := range src {
+		if i != 70 {
+			src[i] = Int64P(int64(i))
+		}
+	}
+	c := NewColumn(src)
+	if e, a := Sum(src), SumColumn(c); e != a {
+		t.Errorf("Unexpected sum %d", a)
+	}
+	if m, ok := MaxColumn(c); m != 199 || !ok {
+		t.Errorf("Unexpected max %d", m)
+	}
+}
+
+const benchColumnLen = 1 << 20
+
+func benchColumnSource() []*float64 {
+	src := make([]*float64, benchColumnLen)
+	for i := range src {
+		if i%10 != 0 {
+			src[i] = Float64P(float64(i))
+		}
+	}
+	return src
+}
+
+func BenchmarkBuildPtrSlice(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var s []*float64
+		for j := 0; j < benchColumnLen; j++ {
+			if j%10 == 0 {
+				s = append(s, nil)
+			} else {
+				s = append(s, Float64P(float64(j)))
+			}
+		}
+	}
+}
+
+func BenchmarkBuildColumn(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var c Column[float64]
+		for j := 0; j < benchColumnLen; j++ {
+			if j%10 == 0 {
+				c.Append(nil)
+			} else {
+				v := float64(j)
+				c.Append(&v)
+			}
+		}
+	}
+}
+
+func BenchmarkSumPtrSlice(b *testing.B) {
+	src := benchColumnSource()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sum(src)
+	}
+}
+
+func BenchmarkSumColumn(b *testing.B) {
+	c := NewColumn(benchColumnSource())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SumColumn(c)
+	}
+}
+
+func BenchmarkMaxPtrSlice(b *testing.B) {
+	src := benchColumnSource()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Max(src)
+	}
+}
+
+func BenchmarkMaxColumn(b *testing.B) {
+	c := NewColumn(benchColumnSource())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MaxColumn(c)
+	}
+}
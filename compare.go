@@ -0,0 +1,101 @@
+package pointer
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"time"
+)
+
+// NilOrder selects where nil pointers are ordered relative to non-nil ones.
+type NilOrder int
+
+const (
+	// NilsFirst orders nil pointers before all non-nil ones.
+	NilsFirst NilOrder = iota
+	// NilsLast orders nil pointers after all non-nil ones.
+	NilsLast
+)
+
+// Compare compares the values a and b point to, as cmp.Compare does,
+// ordering nil pointers according to order. Two nil pointers are equal.
+func Compare[T cmp.Ordered](a, b *T, order NilOrder) int {
+	return CompareFunc(a, b, cmp.Compare[T], order)
+}
+
+// CompareTime compares the times a and b point to, ordering nil pointers
+// according to order.
+func CompareTime(a, b *time.Time, order NilOrder) int {
+	return CompareFunc(a, b, time.Time.Compare, order)
+}
+
+// CompareFunc compares the values a and b point to with cmp, ordering nil
+// pointers according to order.
+func CompareFunc[T any](a, b *T, cmp func(T, T) int, order NilOrder) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		if order == NilsLast {
+			return 1
+		}
+		return -1
+	case b == nil:
+		if order == NilsLast {
+			return -1
+		}
+		return 1
+	}
+	return cmp(*a, *b)
+}
+
+// SortSlice sorts s in ascending order of the values its elements point
+// to, placing nil elements according to order.
+func SortSlice[T cmp.Ordered](s []*T, order NilOrder) {
+	SortSliceFunc(s, cmp.Compare[T], order)
+}
+
+// SortStableSlice is like SortSlice but keeps equal elements, including
+// nils, in their original order.
+func SortStableSlice[T cmp.Ordered](s []*T, order NilOrder) {
+	SortStableSliceFunc(s, cmp.Compare[T], order)
+}
+
+// SortSliceFunc sorts s in ascending order of the values its elements
+// point to as determined by cmp, placing nil elements according to order.
+// For example, a []*time.Time is sorted by
+//
+//	SortSliceFunc(s, time.Time.Compare, NilsLast)
+func SortSliceFunc[T any](s []*T, cmp func(T, T) int, order NilOrder) {
+	slices.SortFunc(s, func(a, b *T) int {
+		return CompareFunc(a, b, cmp, order)
+	})
+}
+
+// SortStableSliceFunc is like SortSliceFunc but keeps equal elements,
+// including nils, in their original order.
+func SortStableSliceFunc[T any](s []*T, cmp func(T, T) int, order NilOrder) {
+	slices.SortStableFunc(s, func(a, b *T) int {
+		return CompareFunc(a, b, cmp, order)
+	})
+}
+
+// SortedKeys returns the keys of m in ascending order.
+func SortedKeys[T any](m map[string]*T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RangeSorted calls fn for each entry of m in ascending key order,
+// stopping early if fn returns false.
+func RangeSorted[T any](m map[string]*T, fn func(k string, v *T) bool) {
+	for _, k := range SortedKeys(m) {
+		if !fn(k, m[k]) {
+			return
+		}
+	}
+}
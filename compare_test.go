@@ -0,0 +1,82 @@
+package pointer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b  *int32
+		order NilOrder
+		out   int
+	}{
+		{Int32P(1), Int32P(2), NilsFirst, -1},
+		{Int32P(2), Int32P(2), NilsLast, 0},
+		{Int32P(3), Int32P(2), NilsFirst, 1},
+		{nil, Int32P(2), NilsFirst, -1},
+		{nil, Int32P(2), NilsLast, 1},
+		{Int32P(2), nil, NilsFirst, 1},
+		{Int32P(2), nil, NilsLast, -1},
+		{nil, nil, NilsLast, 0},
+	}
+	for idx, c := range cases {
+		if a := Compare(c.a, c.b, c.order); a != c.out {
+			t.Errorf("Unexpected result %d at idx %d", a, idx)
+		}
+	}
+
+	now := time.Now()
+	if a := CompareTime(TimeP(now), TimeP(now.Add(time.Second)), NilsFirst); a != -1 {
+		t.Errorf("Unexpected result %d", a)
+	}
+	if a := CompareTime(nil, TimeP(now), NilsLast); a != 1 {
+		t.Errorf("Unexpected result %d", a)
+	}
+}
+
+func TestSortSlice(t *testing.T) {
+	s := []*int32{Int32P(3), nil, Int32P(1), Int32P(2), nil}
+	SortSlice(s, NilsFirst)
+	if s[0] != nil || s[1] != nil || !reflect.DeepEqual([]int32{1, 2, 3}, Int32Slice(s[2:])) {
+		t.Errorf("Unexpected order %v", Int32Slice(s))
+	}
+	SortSlice(s, NilsLast)
+	if s[3] != nil || s[4] != nil || !reflect.DeepEqual([]int32{1, 2, 3}, Int32Slice(s[:3])) {
+		t.Errorf("Unexpected order %v", Int32Slice(s))
+	}
+
+	a, b := StringP("x"), StringP("x")
+	ss := []*string{StringP("y"), a, nil, b}
+	SortStableSlice(ss, NilsLast)
+	if ss[0] != a || ss[1] != b || *ss[2] != "y" || ss[3] != nil {
+		t.Errorf("Unexpected order %v", StringSlice(ss))
+	}
+
+	now := time.Now()
+	ts := []*time.Time{TimeP(now.Add(time.Hour)), nil, TimeP(now)}
+	SortSliceFunc(ts, time.Time.Compare, NilsLast)
+	if !ts[0].Equal(now) || !ts[1].Equal(now.Add(time.Hour)) || ts[2] != nil {
+		t.Errorf("Unexpected order %v", ts)
+	}
+	SortStableSliceFunc(ts, time.Time.Compare, NilsFirst)
+	if ts[0] != nil || !ts[1].Equal(now) {
+		t.Errorf("Unexpected order %v", ts)
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	m := map[string]*int{"b": IntP(2), "a": nil, "c": IntP(3)}
+	if e, a := []string{"a", "b", "c"}, SortedKeys(m); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected keys %v", a)
+	}
+	var seen []string
+	RangeSorted(m, func(k string, v *int) bool {
+		seen = append(seen, k)
+		return k != "b"
+	})
+	if e, a := []string{"a", "b"}, seen; !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected keys %v", a)
+	}
+}
@@ -0,0 +1,38 @@
+package pointer
+
+import "time"
+
+// Signed is the set of signed integer types covered by this package.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is the set of unsigned integer types covered by this package.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Integer is the set of integer types covered by this package.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is the set of floating-point types covered by this package.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is the set of numeric types covered by this package.
+type Number interface {
+	Integer | Float
+}
+
+// Binary is the set of types supported by the binary codec: the exact
+// types of every family in convert_types.go.
+type Binary interface {
+	bool | string |
+		int | int8 | int16 | int32 | int64 |
+		uint | uint8 | uint16 | uint32 | uint64 |
+		float32 | float64 |
+		time.Duration | time.Time
+}
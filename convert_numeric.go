@@ -0,0 +1,169 @@
+package pointer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+//go:generate go run gen_numeric.go
+
+// ErrInexact is returned when converting a float with a fractional part to
+// an integer type.
+var ErrInexact = errors.New("pointer: conversion loses fractional part")
+
+// ConvertP converts the number p points to into a new *To, failing with
+// ErrOverflow if it is out of the range of To and with ErrInexact if a
+// float with a fractional part is converted to an integer type. Converting
+// to a float type may round. A nil p yields nil.
+func ConvertP[From, To Number](p *From) (*To, error) {
+	if p == nil {
+		return nil, nil
+	}
+	v, err := convertNumeric[From, To](*p)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// MustConvertP is like ConvertP but panics if the conversion fails.
+func MustConvertP[From, To Number](p *From) *To {
+	v, err := ConvertP[From, To](p)
+	if err != nil {
+		panic(fmt.Sprintf("%v: %v", err, *p))
+	}
+	return v
+}
+
+// SaturateP is like ConvertP but clamps out of range values to the
+// nearest value representable by To, and truncates fractions toward zero.
+// NaN converts to zero for integer types.
+func SaturateP[From, To Number](p *From) *To {
+	if p == nil {
+		return nil
+	}
+	v := saturateNumeric[From, To](*p)
+	return &v
+}
+
+// ConvertPSlice applies ConvertP to every element of src, failing with the
+// first error encountered.
+func ConvertPSlice[From, To Number](src []*From) ([]*To, error) {
+	dst := make([]*To, len(src))
+	for i := 0; i < len(src); i++ {
+		v, err := ConvertP[From, To](src[i])
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %w", i, err)
+		}
+		dst[i] = v
+	}
+	return dst, nil
+}
+
+// SaturatePSlice applies SaturateP to every element of src.
+func SaturatePSlice[From, To Number](src []*From) []*To {
+	dst := make([]*To, len(src))
+	for i := 0; i < len(src); i++ {
+		dst[i] = SaturateP[From, To](src[i])
+	}
+	return dst
+}
+
+// ConvertPMap applies ConvertP to every value of src, failing with the
+// first error encountered.
+func ConvertPMap[From, To Number](src map[string]*From) (map[string]*To, error) {
+	dst := make(map[string]*To)
+	for k, val := range src {
+		v, err := ConvertP[From, To](val)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k, err)
+		}
+		dst[k] = v
+	}
+	return dst, nil
+}
+
+// SaturatePMap applies SaturateP to every value of src.
+func SaturatePMap[From, To Number](src map[string]*From) map[string]*To {
+	dst := make(map[string]*To)
+	for k, val := range src {
+		dst[k] = SaturateP[From, To](val)
+	}
+	return dst
+}
+
+// numericKind describes the representation of a numeric type.
+func numericKind[T Number]() (isFloat, signed bool, bits int) {
+	var z T
+	return T(1)/2 != 0, z-1 < 0, int(unsafe.Sizeof(z)) * 8
+}
+
+// integerBounds returns the range [lo, hi) of an integer type as floats.
+func integerBounds(signed bool, bits int) (lo, hi float64) {
+	if signed {
+		return -math.Ldexp(1, bits-1), math.Ldexp(1, bits-1)
+	}
+	return 0, math.Ldexp(1, bits)
+}
+
+func convertNumeric[From, To Number](v From) (To, error) {
+	fromFloat, _, _ := numericKind[From]()
+	toFloat, toSigned, toBits := numericKind[To]()
+	switch {
+	case toFloat:
+		t := To(v)
+		if f := float64(t); math.IsInf(f, 0) && !math.IsInf(float64(v), 0) {
+			return 0, ErrOverflow
+		}
+		return t, nil
+	case fromFloat:
+		f := float64(v)
+		lo, hi := integerBounds(toSigned, toBits)
+		if f != f || f < lo || f >= hi {
+			return 0, ErrOverflow
+		}
+		if f != math.Trunc(f) {
+			return 0, ErrInexact
+		}
+		return To(f), nil
+	}
+	t := To(v)
+	if From(t) != v || (v < 0) != (t < 0) {
+		return 0, ErrOverflow
+	}
+	return t, nil
+}
+
+func saturateNumeric[From, To Number](v From) To {
+	t, err := convertNumeric[From, To](v)
+	if err == nil {
+		return t
+	}
+	toFloat, toSigned, toBits := numericKind[To]()
+	if toFloat {
+		// Only float64 to float32 can overflow.
+		m := math.MaxFloat32
+		if v < 0 {
+			m = -m
+		}
+		return To(m)
+	}
+	f := float64(v)
+	if errors.Is(err, ErrInexact) {
+		return To(math.Trunc(f))
+	}
+	switch {
+	case f != f:
+		return 0
+	case v < 0:
+		if !toSigned {
+			return 0
+		}
+		return To(int64(math.MinInt64) >> (64 - toBits))
+	case toSigned:
+		return To(int64(math.MaxInt64) >> (64 - toBits))
+	}
+	return To(uint64(math.MaxUint64) >> (64 - toBits))
+}
@@ -0,0 +1,795 @@
+// Code generated by gen_numeric.go; DO NOT EDIT.
+
+package pointer
+
+// IntPToInt8P converts an int pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToInt8P(p *int) (*int8, error) {
+	return ConvertP[int, int8](p)
+}
+
+// IntPToInt16P converts an int pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToInt16P(p *int) (*int16, error) {
+	return ConvertP[int, int16](p)
+}
+
+// IntPToInt32P converts an int pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToInt32P(p *int) (*int32, error) {
+	return ConvertP[int, int32](p)
+}
+
+// IntPToInt64P converts an int pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToInt64P(p *int) (*int64, error) {
+	return ConvertP[int, int64](p)
+}
+
+// IntPToUintP converts an int pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToUintP(p *int) (*uint, error) {
+	return ConvertP[int, uint](p)
+}
+
+// IntPToUint8P converts an int pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToUint8P(p *int) (*uint8, error) {
+	return ConvertP[int, uint8](p)
+}
+
+// IntPToUint16P converts an int pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToUint16P(p *int) (*uint16, error) {
+	return ConvertP[int, uint16](p)
+}
+
+// IntPToUint32P converts an int pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToUint32P(p *int) (*uint32, error) {
+	return ConvertP[int, uint32](p)
+}
+
+// IntPToUint64P converts an int pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToUint64P(p *int) (*uint64, error) {
+	return ConvertP[int, uint64](p)
+}
+
+// IntPToFloat32P converts an int pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToFloat32P(p *int) (*float32, error) {
+	return ConvertP[int, float32](p)
+}
+
+// IntPToFloat64P converts an int pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func IntPToFloat64P(p *int) (*float64, error) {
+	return ConvertP[int, float64](p)
+}
+
+// Int8PToIntP converts an int8 pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToIntP(p *int8) (*int, error) {
+	return ConvertP[int8, int](p)
+}
+
+// Int8PToInt16P converts an int8 pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToInt16P(p *int8) (*int16, error) {
+	return ConvertP[int8, int16](p)
+}
+
+// Int8PToInt32P converts an int8 pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToInt32P(p *int8) (*int32, error) {
+	return ConvertP[int8, int32](p)
+}
+
+// Int8PToInt64P converts an int8 pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToInt64P(p *int8) (*int64, error) {
+	return ConvertP[int8, int64](p)
+}
+
+// Int8PToUintP converts an int8 pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToUintP(p *int8) (*uint, error) {
+	return ConvertP[int8, uint](p)
+}
+
+// Int8PToUint8P converts an int8 pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToUint8P(p *int8) (*uint8, error) {
+	return ConvertP[int8, uint8](p)
+}
+
+// Int8PToUint16P converts an int8 pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToUint16P(p *int8) (*uint16, error) {
+	return ConvertP[int8, uint16](p)
+}
+
+// Int8PToUint32P converts an int8 pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToUint32P(p *int8) (*uint32, error) {
+	return ConvertP[int8, uint32](p)
+}
+
+// Int8PToUint64P converts an int8 pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToUint64P(p *int8) (*uint64, error) {
+	return ConvertP[int8, uint64](p)
+}
+
+// Int8PToFloat32P converts an int8 pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToFloat32P(p *int8) (*float32, error) {
+	return ConvertP[int8, float32](p)
+}
+
+// Int8PToFloat64P converts an int8 pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int8PToFloat64P(p *int8) (*float64, error) {
+	return ConvertP[int8, float64](p)
+}
+
+// Int16PToIntP converts an int16 pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToIntP(p *int16) (*int, error) {
+	return ConvertP[int16, int](p)
+}
+
+// Int16PToInt8P converts an int16 pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToInt8P(p *int16) (*int8, error) {
+	return ConvertP[int16, int8](p)
+}
+
+// Int16PToInt32P converts an int16 pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToInt32P(p *int16) (*int32, error) {
+	return ConvertP[int16, int32](p)
+}
+
+// Int16PToInt64P converts an int16 pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToInt64P(p *int16) (*int64, error) {
+	return ConvertP[int16, int64](p)
+}
+
+// Int16PToUintP converts an int16 pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToUintP(p *int16) (*uint, error) {
+	return ConvertP[int16, uint](p)
+}
+
+// Int16PToUint8P converts an int16 pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToUint8P(p *int16) (*uint8, error) {
+	return ConvertP[int16, uint8](p)
+}
+
+// Int16PToUint16P converts an int16 pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToUint16P(p *int16) (*uint16, error) {
+	return ConvertP[int16, uint16](p)
+}
+
+// Int16PToUint32P converts an int16 pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToUint32P(p *int16) (*uint32, error) {
+	return ConvertP[int16, uint32](p)
+}
+
+// Int16PToUint64P converts an int16 pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToUint64P(p *int16) (*uint64, error) {
+	return ConvertP[int16, uint64](p)
+}
+
+// Int16PToFloat32P converts an int16 pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToFloat32P(p *int16) (*float32, error) {
+	return ConvertP[int16, float32](p)
+}
+
+// Int16PToFloat64P converts an int16 pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int16PToFloat64P(p *int16) (*float64, error) {
+	return ConvertP[int16, float64](p)
+}
+
+// Int32PToIntP converts an int32 pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToIntP(p *int32) (*int, error) {
+	return ConvertP[int32, int](p)
+}
+
+// Int32PToInt8P converts an int32 pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToInt8P(p *int32) (*int8, error) {
+	return ConvertP[int32, int8](p)
+}
+
+// Int32PToInt16P converts an int32 pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToInt16P(p *int32) (*int16, error) {
+	return ConvertP[int32, int16](p)
+}
+
+// Int32PToInt64P converts an int32 pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToInt64P(p *int32) (*int64, error) {
+	return ConvertP[int32, int64](p)
+}
+
+// Int32PToUintP converts an int32 pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToUintP(p *int32) (*uint, error) {
+	return ConvertP[int32, uint](p)
+}
+
+// Int32PToUint8P converts an int32 pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToUint8P(p *int32) (*uint8, error) {
+	return ConvertP[int32, uint8](p)
+}
+
+// Int32PToUint16P converts an int32 pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToUint16P(p *int32) (*uint16, error) {
+	return ConvertP[int32, uint16](p)
+}
+
+// Int32PToUint32P converts an int32 pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToUint32P(p *int32) (*uint32, error) {
+	return ConvertP[int32, uint32](p)
+}
+
+// Int32PToUint64P converts an int32 pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToUint64P(p *int32) (*uint64, error) {
+	return ConvertP[int32, uint64](p)
+}
+
+// Int32PToFloat32P converts an int32 pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToFloat32P(p *int32) (*float32, error) {
+	return ConvertP[int32, float32](p)
+}
+
+// Int32PToFloat64P converts an int32 pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int32PToFloat64P(p *int32) (*float64, error) {
+	return ConvertP[int32, float64](p)
+}
+
+// Int64PToIntP converts an int64 pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToIntP(p *int64) (*int, error) {
+	return ConvertP[int64, int](p)
+}
+
+// Int64PToInt8P converts an int64 pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToInt8P(p *int64) (*int8, error) {
+	return ConvertP[int64, int8](p)
+}
+
+// Int64PToInt16P converts an int64 pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToInt16P(p *int64) (*int16, error) {
+	return ConvertP[int64, int16](p)
+}
+
+// Int64PToInt32P converts an int64 pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToInt32P(p *int64) (*int32, error) {
+	return ConvertP[int64, int32](p)
+}
+
+// Int64PToUintP converts an int64 pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToUintP(p *int64) (*uint, error) {
+	return ConvertP[int64, uint](p)
+}
+
+// Int64PToUint8P converts an int64 pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToUint8P(p *int64) (*uint8, error) {
+	return ConvertP[int64, uint8](p)
+}
+
+// Int64PToUint16P converts an int64 pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToUint16P(p *int64) (*uint16, error) {
+	return ConvertP[int64, uint16](p)
+}
+
+// Int64PToUint32P converts an int64 pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToUint32P(p *int64) (*uint32, error) {
+	return ConvertP[int64, uint32](p)
+}
+
+// Int64PToUint64P converts an int64 pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToUint64P(p *int64) (*uint64, error) {
+	return ConvertP[int64, uint64](p)
+}
+
+// Int64PToFloat32P converts an int64 pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToFloat32P(p *int64) (*float32, error) {
+	return ConvertP[int64, float32](p)
+}
+
+// Int64PToFloat64P converts an int64 pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Int64PToFloat64P(p *int64) (*float64, error) {
+	return ConvertP[int64, float64](p)
+}
+
+// UintPToIntP converts a uint pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToIntP(p *uint) (*int, error) {
+	return ConvertP[uint, int](p)
+}
+
+// UintPToInt8P converts a uint pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToInt8P(p *uint) (*int8, error) {
+	return ConvertP[uint, int8](p)
+}
+
+// UintPToInt16P converts a uint pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToInt16P(p *uint) (*int16, error) {
+	return ConvertP[uint, int16](p)
+}
+
+// UintPToInt32P converts a uint pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToInt32P(p *uint) (*int32, error) {
+	return ConvertP[uint, int32](p)
+}
+
+// UintPToInt64P converts a uint pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToInt64P(p *uint) (*int64, error) {
+	return ConvertP[uint, int64](p)
+}
+
+// UintPToUint8P converts a uint pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToUint8P(p *uint) (*uint8, error) {
+	return ConvertP[uint, uint8](p)
+}
+
+// UintPToUint16P converts a uint pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToUint16P(p *uint) (*uint16, error) {
+	return ConvertP[uint, uint16](p)
+}
+
+// UintPToUint32P converts a uint pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToUint32P(p *uint) (*uint32, error) {
+	return ConvertP[uint, uint32](p)
+}
+
+// UintPToUint64P converts a uint pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToUint64P(p *uint) (*uint64, error) {
+	return ConvertP[uint, uint64](p)
+}
+
+// UintPToFloat32P converts a uint pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToFloat32P(p *uint) (*float32, error) {
+	return ConvertP[uint, float32](p)
+}
+
+// UintPToFloat64P converts a uint pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func UintPToFloat64P(p *uint) (*float64, error) {
+	return ConvertP[uint, float64](p)
+}
+
+// Uint8PToIntP converts a uint8 pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToIntP(p *uint8) (*int, error) {
+	return ConvertP[uint8, int](p)
+}
+
+// Uint8PToInt8P converts a uint8 pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToInt8P(p *uint8) (*int8, error) {
+	return ConvertP[uint8, int8](p)
+}
+
+// Uint8PToInt16P converts a uint8 pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToInt16P(p *uint8) (*int16, error) {
+	return ConvertP[uint8, int16](p)
+}
+
+// Uint8PToInt32P converts a uint8 pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToInt32P(p *uint8) (*int32, error) {
+	return ConvertP[uint8, int32](p)
+}
+
+// Uint8PToInt64P converts a uint8 pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToInt64P(p *uint8) (*int64, error) {
+	return ConvertP[uint8, int64](p)
+}
+
+// Uint8PToUintP converts a uint8 pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToUintP(p *uint8) (*uint, error) {
+	return ConvertP[uint8, uint](p)
+}
+
+// Uint8PToUint16P converts a uint8 pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToUint16P(p *uint8) (*uint16, error) {
+	return ConvertP[uint8, uint16](p)
+}
+
+// Uint8PToUint32P converts a uint8 pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToUint32P(p *uint8) (*uint32, error) {
+	return ConvertP[uint8, uint32](p)
+}
+
+// Uint8PToUint64P converts a uint8 pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToUint64P(p *uint8) (*uint64, error) {
+	return ConvertP[uint8, uint64](p)
+}
+
+// Uint8PToFloat32P converts a uint8 pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToFloat32P(p *uint8) (*float32, error) {
+	return ConvertP[uint8, float32](p)
+}
+
+// Uint8PToFloat64P converts a uint8 pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint8PToFloat64P(p *uint8) (*float64, error) {
+	return ConvertP[uint8, float64](p)
+}
+
+// Uint16PToIntP converts a uint16 pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToIntP(p *uint16) (*int, error) {
+	return ConvertP[uint16, int](p)
+}
+
+// Uint16PToInt8P converts a uint16 pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToInt8P(p *uint16) (*int8, error) {
+	return ConvertP[uint16, int8](p)
+}
+
+// Uint16PToInt16P converts a uint16 pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToInt16P(p *uint16) (*int16, error) {
+	return ConvertP[uint16, int16](p)
+}
+
+// Uint16PToInt32P converts a uint16 pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToInt32P(p *uint16) (*int32, error) {
+	return ConvertP[uint16, int32](p)
+}
+
+// Uint16PToInt64P converts a uint16 pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToInt64P(p *uint16) (*int64, error) {
+	return ConvertP[uint16, int64](p)
+}
+
+// Uint16PToUintP converts a uint16 pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToUintP(p *uint16) (*uint, error) {
+	return ConvertP[uint16, uint](p)
+}
+
+// Uint16PToUint8P converts a uint16 pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToUint8P(p *uint16) (*uint8, error) {
+	return ConvertP[uint16, uint8](p)
+}
+
+// Uint16PToUint32P converts a uint16 pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToUint32P(p *uint16) (*uint32, error) {
+	return ConvertP[uint16, uint32](p)
+}
+
+// Uint16PToUint64P converts a uint16 pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToUint64P(p *uint16) (*uint64, error) {
+	return ConvertP[uint16, uint64](p)
+}
+
+// Uint16PToFloat32P converts a uint16 pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToFloat32P(p *uint16) (*float32, error) {
+	return ConvertP[uint16, float32](p)
+}
+
+// Uint16PToFloat64P converts a uint16 pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint16PToFloat64P(p *uint16) (*float64, error) {
+	return ConvertP[uint16, float64](p)
+}
+
+// Uint32PToIntP converts a uint32 pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToIntP(p *uint32) (*int, error) {
+	return ConvertP[uint32, int](p)
+}
+
+// Uint32PToInt8P converts a uint32 pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToInt8P(p *uint32) (*int8, error) {
+	return ConvertP[uint32, int8](p)
+}
+
+// Uint32PToInt16P converts a uint32 pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToInt16P(p *uint32) (*int16, error) {
+	return ConvertP[uint32, int16](p)
+}
+
+// Uint32PToInt32P converts a uint32 pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToInt32P(p *uint32) (*int32, error) {
+	return ConvertP[uint32, int32](p)
+}
+
+// Uint32PToInt64P converts a uint32 pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToInt64P(p *uint32) (*int64, error) {
+	return ConvertP[uint32, int64](p)
+}
+
+// Uint32PToUintP converts a uint32 pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToUintP(p *uint32) (*uint, error) {
+	return ConvertP[uint32, uint](p)
+}
+
+// Uint32PToUint8P converts a uint32 pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToUint8P(p *uint32) (*uint8, error) {
+	return ConvertP[uint32, uint8](p)
+}
+
+// Uint32PToUint16P converts a uint32 pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToUint16P(p *uint32) (*uint16, error) {
+	return ConvertP[uint32, uint16](p)
+}
+
+// Uint32PToUint64P converts a uint32 pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToUint64P(p *uint32) (*uint64, error) {
+	return ConvertP[uint32, uint64](p)
+}
+
+// Uint32PToFloat32P converts a uint32 pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToFloat32P(p *uint32) (*float32, error) {
+	return ConvertP[uint32, float32](p)
+}
+
+// Uint32PToFloat64P converts a uint32 pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint32PToFloat64P(p *uint32) (*float64, error) {
+	return ConvertP[uint32, float64](p)
+}
+
+// Uint64PToIntP converts a uint64 pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToIntP(p *uint64) (*int, error) {
+	return ConvertP[uint64, int](p)
+}
+
+// Uint64PToInt8P converts a uint64 pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToInt8P(p *uint64) (*int8, error) {
+	return ConvertP[uint64, int8](p)
+}
+
+// Uint64PToInt16P converts a uint64 pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToInt16P(p *uint64) (*int16, error) {
+	return ConvertP[uint64, int16](p)
+}
+
+// Uint64PToInt32P converts a uint64 pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToInt32P(p *uint64) (*int32, error) {
+	return ConvertP[uint64, int32](p)
+}
+
+// Uint64PToInt64P converts a uint64 pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToInt64P(p *uint64) (*int64, error) {
+	return ConvertP[uint64, int64](p)
+}
+
+// Uint64PToUintP converts a uint64 pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToUintP(p *uint64) (*uint, error) {
+	return ConvertP[uint64, uint](p)
+}
+
+// Uint64PToUint8P converts a uint64 pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToUint8P(p *uint64) (*uint8, error) {
+	return ConvertP[uint64, uint8](p)
+}
+
+// Uint64PToUint16P converts a uint64 pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToUint16P(p *uint64) (*uint16, error) {
+	return ConvertP[uint64, uint16](p)
+}
+
+// Uint64PToUint32P converts a uint64 pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToUint32P(p *uint64) (*uint32, error) {
+	return ConvertP[uint64, uint32](p)
+}
+
+// Uint64PToFloat32P converts a uint64 pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToFloat32P(p *uint64) (*float32, error) {
+	return ConvertP[uint64, float32](p)
+}
+
+// Uint64PToFloat64P converts a uint64 pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Uint64PToFloat64P(p *uint64) (*float64, error) {
+	return ConvertP[uint64, float64](p)
+}
+
+// Float32PToIntP converts a float32 pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToIntP(p *float32) (*int, error) {
+	return ConvertP[float32, int](p)
+}
+
+// Float32PToInt8P converts a float32 pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToInt8P(p *float32) (*int8, error) {
+	return ConvertP[float32, int8](p)
+}
+
+// Float32PToInt16P converts a float32 pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToInt16P(p *float32) (*int16, error) {
+	return ConvertP[float32, int16](p)
+}
+
+// Float32PToInt32P converts a float32 pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToInt32P(p *float32) (*int32, error) {
+	return ConvertP[float32, int32](p)
+}
+
+// Float32PToInt64P converts a float32 pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToInt64P(p *float32) (*int64, error) {
+	return ConvertP[float32, int64](p)
+}
+
+// Float32PToUintP converts a float32 pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToUintP(p *float32) (*uint, error) {
+	return ConvertP[float32, uint](p)
+}
+
+// Float32PToUint8P converts a float32 pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToUint8P(p *float32) (*uint8, error) {
+	return ConvertP[float32, uint8](p)
+}
+
+// Float32PToUint16P converts a float32 pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToUint16P(p *float32) (*uint16, error) {
+	return ConvertP[float32, uint16](p)
+}
+
+// Float32PToUint32P converts a float32 pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToUint32P(p *float32) (*uint32, error) {
+	return ConvertP[float32, uint32](p)
+}
+
+// Float32PToUint64P converts a float32 pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToUint64P(p *float32) (*uint64, error) {
+	return ConvertP[float32, uint64](p)
+}
+
+// Float32PToFloat64P converts a float32 pointer into a float64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float32PToFloat64P(p *float32) (*float64, error) {
+	return ConvertP[float32, float64](p)
+}
+
+// Float64PToIntP converts a float64 pointer into an int pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToIntP(p *float64) (*int, error) {
+	return ConvertP[float64, int](p)
+}
+
+// Float64PToInt8P converts a float64 pointer into an int8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToInt8P(p *float64) (*int8, error) {
+	return ConvertP[float64, int8](p)
+}
+
+// Float64PToInt16P converts a float64 pointer into an int16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToInt16P(p *float64) (*int16, error) {
+	return ConvertP[float64, int16](p)
+}
+
+// Float64PToInt32P converts a float64 pointer into an int32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToInt32P(p *float64) (*int32, error) {
+	return ConvertP[float64, int32](p)
+}
+
+// Float64PToInt64P converts a float64 pointer into an int64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToInt64P(p *float64) (*int64, error) {
+	return ConvertP[float64, int64](p)
+}
+
+// Float64PToUintP converts a float64 pointer into a uint pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToUintP(p *float64) (*uint, error) {
+	return ConvertP[float64, uint](p)
+}
+
+// Float64PToUint8P converts a float64 pointer into a uint8 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToUint8P(p *float64) (*uint8, error) {
+	return ConvertP[float64, uint8](p)
+}
+
+// Float64PToUint16P converts a float64 pointer into a uint16 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToUint16P(p *float64) (*uint16, error) {
+	return ConvertP[float64, uint16](p)
+}
+
+// Float64PToUint32P converts a float64 pointer into a uint32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToUint32P(p *float64) (*uint32, error) {
+	return ConvertP[float64, uint32](p)
+}
+
+// Float64PToUint64P converts a float64 pointer into a uint64 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToUint64P(p *float64) (*uint64, error) {
+	return ConvertP[float64, uint64](p)
+}
+
+// Float64PToFloat32P converts a float64 pointer into a float32 pointer, failing if
+// the value does not fit. See ConvertP.
+func Float64PToFloat32P(p *float64) (*float32, error) {
+	return ConvertP[float64, float32](p)
+}
@@ -0,0 +1,117 @@
+package pointer
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestConvertP(t *testing.T) {
+	if v, err := Int64PToInt32P(Int64P(math.MaxInt32)); err != nil || *v != math.MaxInt32 {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v, err := Int64PToInt32P(nil); err != nil || v != nil {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+
+	overflows := []func() error{
+		func() error { _, err := Int64PToInt32P(Int64P(math.MaxInt32 + 1)); return err },
+		func() error { _, err := Int64PToInt32P(Int64P(math.MinInt32 - 1)); return err },
+		func() error { _, err := IntPToUintP(IntP(-1)); return err },
+		func() error { _, err := Uint64PToInt64P(Uint64P(math.MaxUint64)); return err },
+		func() error { _, err := Int16PToUint8P(Int16P(256)); return err },
+		func() error { _, err := Float64PToInt8P(Float64P(128)); return err },
+		func() error { _, err := Float64PToUint64P(Float64P(math.Ldexp(1, 64))); return err },
+		func() error { _, err := Float64PToInt64P(Float64P(math.NaN())); return err },
+		func() error { _, err := Float64PToFloat32P(Float64P(math.MaxFloat64)); return err },
+	}
+	for idx, f := range overflows {
+		if err := f(); !errors.Is(err, ErrOverflow) {
+			t.Errorf("Expected overflow at idx %d, got %v", idx, err)
+		}
+	}
+	if _, err := Float32PToIntP(Float32P(1.5)); !errors.Is(err, ErrInexact) {
+		t.Errorf("Expected inexact conversion, got %v", err)
+	}
+	if v, err := Float64PToInt8P(Float64P(-128)); err != nil || *v != -128 {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v, err := Float64PToFloat32P(Float64P(math.Inf(-1))); err != nil || !math.IsInf(float64(*v), -1) {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v, err := Uint8PToFloat64P(Uint8P(255)); err != nil || *v != 255 {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+}
+
+func TestSaturateP(t *testing.T) {
+	cases := []struct {
+		in  *float64
+		out *int8
+	}{
+		{Float64P(1000), Int8P(math.MaxInt8)},
+		{Float64P(-1000), Int8P(math.MinInt8)},
+		{Float64P(-2.7), Int8P(-2)},
+		{Float64P(math.NaN()), Int8P(0)},
+		{nil, nil},
+	}
+	for idx, c := range cases {
+		if a := SaturateP[float64, int8](c.in); !reflect.DeepEqual(c.out, a) {
+			t.Errorf("Unexpected value %v at idx %d", Int8(a), idx)
+		}
+	}
+	if a := *SaturateP[int64, uint16](Int64P(-5)); a != 0 {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if a := *SaturateP[int64, uint16](Int64P(1 << 40)); a != math.MaxUint16 {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if a := *SaturateP[uint64, int64](Uint64P(math.MaxUint64)); a != math.MaxInt64 {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if a := *SaturateP[float64, float32](Float64P(-math.MaxFloat64)); a != -math.MaxFloat32 {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if a := *SaturateP[int, int32](IntP(7)); a != 7 {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
+
+func TestMustConvertP(t *testing.T) {
+	if a := *MustConvertP[int, int64](IntP(3)); a != 3 {
+		t.Errorf("Unexpected value %v", a)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic")
+		}
+	}()
+	MustConvertP[int, uint](IntP(-3))
+}
+
+func TestConvertPSlice(t *testing.T) {
+	out, err := ConvertPSlice[int64, int32]([]*int64{Int64P(1), nil, Int64P(3)})
+	if err != nil || !reflect.DeepEqual([]*int32{Int32P(1), nil, Int32P(3)}, out) {
+		t.Errorf("Unexpected value %v %v", out, err)
+	}
+	if _, err := ConvertPSlice[int64, int32]([]*int64{nil, Int64P(1 << 40)}); !errors.Is(err, ErrOverflow) || err.Error() != "index 1: pointer: integer overflow" {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if a := SaturatePSlice[int64, int8]([]*int64{Int64P(1000), nil}); *a[0] != math.MaxInt8 || a[1] != nil {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
+
+func TestConvertPMap(t *testing.T) {
+	out, err := ConvertPMap[uint32, float64](map[string]*uint32{"a": Uint32P(1), "b": nil})
+	if err != nil || !reflect.DeepEqual(map[string]*float64{"a": Float64P(1), "b": nil}, out) {
+		t.Errorf("Unexpected value %v %v", out, err)
+	}
+	if _, err := ConvertPMap[int, uint](map[string]*int{"a": IntP(-1)}); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if a := SaturatePMap[int, uint](map[string]*int{"a": IntP(-1)}); *a["a"] != 0 {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
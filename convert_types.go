@@ -842,6 +842,65 @@ func Float64Map(src map[string]*float64) map[string]float64 {
 	return dst
 }
 
+// DurationP returns a pointer to the time.Duration value passed in.
+func DurationP(v time.Duration) *time.Duration {
+	return &v
+}
+
+// Duration returns the value of the time.Duration pointer passed in or
+// 0 if the pointer is nil.
+func Duration(v *time.Duration) time.Duration {
+	if v != nil {
+		return *v
+	}
+	return 0
+}
+
+// DurationPSlice converts a slice of time.Duration values into a slice of
+// time.Duration pointers
+func DurationPSlice(src []time.Duration) []*time.Duration {
+	dst := make([]*time.Duration, len(src))
+	for i := 0; i < len(src); i++ {
+		dst[i] = &(src[i])
+	}
+	return dst
+}
+
+// DurationSlice converts a slice of time.Duration pointers into a slice of
+// time.Duration values
+func DurationSlice(src []*time.Duration) []time.Duration {
+	dst := make([]time.Duration, len(src))
+	for i := 0; i < len(src); i++ {
+		if src[i] != nil {
+			dst[i] = *(src[i])
+		}
+	}
+	return dst
+}
+
+// DurationPMap converts a string map of time.Duration values into a string
+// map of time.Duration pointers
+func DurationPMap(src map[string]time.Duration) map[string]*time.Duration {
+	dst := make(map[string]*time.Duration)
+	for k, val := range src {
+		v := val
+		dst[k] = &v
+	}
+	return dst
+}
+
+// DurationMap converts a string map of time.Duration pointers into a string
+// map of time.Duration values
+func DurationMap(src map[string]*time.Duration) map[string]time.Duration {
+	dst := make(map[string]time.Duration)
+	for k, val := range src {
+		if val != nil {
+			dst[k] = *val
+		}
+	}
+	return dst
+}
+
 // TimeP returns a pointer to the time.Time value passed in.
 func TimeP(v time.Time) *time.Time {
 	return &v
@@ -1395,6 +1395,107 @@ func TestFloat64Map(t *testing.T) {
 	}
 }
 
+var testCasesDurationSlice = [][]time.Duration{
+	{time.Second, time.Minute, 0, time.Hour},
+}
+
+func TestDurationSlice(t *testing.T) {
+	for idx, in := range testCasesDurationSlice {
+		if in == nil {
+			continue
+		}
+		out := DurationPSlice(in)
+		if e, a := len(out), len(in); e != a {
+			t.Errorf("Unexpected len at idx %d", idx)
+		}
+		for i := range out {
+			if e, a := in[i], *(out[i]); e != a {
+				t.Errorf("Unexpected value at idx %d", idx)
+			}
+		}
+
+		out2 := DurationSlice(out)
+		if e, a := len(out2), len(in); e != a {
+			t.Errorf("Unexpected len at idx %d", idx)
+		}
+		if e, a := in, out2; !reflect.DeepEqual(e, a) {
+			t.Errorf("Unexpected value at idx %d", idx)
+		}
+	}
+}
+
+var testCasesDurationValueSlice = [][]*time.Duration{
+	{DurationP(time.Second), nil, DurationP(0)},
+}
+
+func TestDurationValueSlice(t *testing.T) {
+	for idx, in := range testCasesDurationValueSlice {
+		if in == nil {
+			continue
+		}
+		out := DurationSlice(in)
+		if e, a := len(out), len(in); e != a {
+			t.Errorf("Unexpected len at idx %d", idx)
+		}
+		for i := range out {
+			if in[i] == nil {
+				if out[i] != 0 {
+					t.Errorf("Unexpected value at idx %d", idx)
+				}
+			} else {
+				if e, a := *(in[i]), out[i]; e != a {
+					t.Errorf("Unexpected value at idx %d", idx)
+				}
+			}
+		}
+
+		out2 := DurationPSlice(out)
+		if e, a := len(out2), len(in); e != a {
+			t.Errorf("Unexpected len at idx %d", idx)
+		}
+		for i := range out2 {
+			if in[i] == nil {
+				if *(out2[i]) != 0 {
+					t.Errorf("Unexpected value at idx %d", idx)
+				}
+			} else {
+				if e, a := *in[i], *out2[i]; e != a {
+					t.Errorf("Unexpected value at idx %d", idx)
+				}
+			}
+		}
+	}
+}
+
+var testCasesDurationMap = []map[string]time.Duration{
+	{"a": time.Second, "b": time.Minute, "c": 0},
+}
+
+func TestDurationMap(t *testing.T) {
+	for idx, in := range testCasesDurationMap {
+		if in == nil {
+			continue
+		}
+		out := DurationPMap(in)
+		if e, a := len(out), len(in); e != a {
+			t.Errorf("Unexpected len at idx %d", idx)
+		}
+		for i := range out {
+			if e, a := in[i], *(out[i]); e != a {
+				t.Errorf("Unexpected value at idx %d", idx)
+			}
+		}
+
+		out2 := DurationMap(out)
+		if e, a := len(out2), len(in); e != a {
+			t.Errorf("Unexpected len at idx %d", idx)
+		}
+		if e, a := in, out2; !reflect.DeepEqual(e, a) {
+			t.Errorf("Unexpected value at idx %d", idx)
+		}
+	}
+}
+
 var testCasesTimeSlice = [][]time.Time{
 	{time.Now(), time.Now().AddDate(100, 0, 0)},
 }
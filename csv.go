@@ -0,0 +1,303 @@
+package pointer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// CSVOption configures a CSVReader or CSVWriter.
+type CSVOption func(*csvConfig)
+
+type csvConfig struct {
+	null  string
+	comma rune
+}
+
+// WithCSVNull sets the cell text that stands for a nil pointer, such as
+// `\N`. The default is the empty cell. With a token set, an empty cell is
+// read as an empty string rather than nil, and a CSVWriter refuses a
+// non-nil value that formats as the token, since it would be read back as
+// nil.
+func WithCSVNull(token string) CSVOption {
+	return func(c *csvConfig) {
+		c.null = token
+	}
+}
+
+// WithCSVComma sets the field delimiter. The default is ','.
+func WithCSVComma(r rune) CSVOption {
+	return func(c *csvConfig) {
+		c.comma = r
+	}
+}
+
+// RowError reports a CSV row that could not be read or written.
+type RowError struct {
+	Line   int    // line of the row in the file, starting at 1 for the header
+	Column string // header of the offending column, if any
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("pointer: line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("pointer: line %d: column %s: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+type csvField struct {
+	name  string
+	index int
+}
+
+// csvFields returns the columns of the struct t, named by their
+// `csv:"..."` tags or else by their Go names. A `csv:"-"` tag skips a
+// field. Every field must be a scalar or a pointer to one.
+func csvFields(t reflect.Type) ([]csvField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("pointer: CSV rows must be structs, got %s", t)
+	}
+	var fields []csvField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := sf.Tag.Get("csv")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = sf.Name
+		}
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if !isScalarType(ft) {
+			return nil, &FieldError{Path: sf.Name, Err: fmt.Errorf("%w %s", errUnsupportedType, sf.Type)}
+		}
+		fields = append(fields, csvField{name: name, index: i})
+	}
+	return fields, nil
+}
+
+// CSVReader reads rows of a CSV file into structs of type T. The first
+// row is a header whose columns are matched to the fields of T by name;
+// unknown columns are ignored and fields without a column stay nil.
+type CSVReader[T any] struct {
+	r      *csv.Reader
+	cfg    csvConfig
+	fields []csvField
+	cols   []int // field index of each column, or -1
+	header []string
+	err    error
+}
+
+// NewCSVReader returns a CSVReader that reads from r.
+func NewCSVReader[T any](r io.Reader, opts ...CSVOption) *CSVReader[T] {
+	cfg := csvConfig{comma: ','}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cr := csv.NewReader(r)
+	cr.Comma = cfg.comma
+	cr.ReuseRecord = true
+	return &CSVReader[T]{r: cr, cfg: cfg}
+}
+
+// Header returns the header row, reading it if no row has been read yet.
+func (r *CSVReader[T]) Header() ([]string, error) {
+	if r.header == nil && r.err == nil {
+		r.err = r.readHeader()
+	}
+	return r.header, r.err
+}
+
+func (r *CSVReader[T]) readHeader() error {
+	fields, err := csvFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+	record, err := r.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return err
+		}
+		return csvReadError(err)
+	}
+	r.fields = fields
+	r.header = append([]string(nil), record...)
+	r.cols = make([]int, len(record))
+	for i, name := range record {
+		r.cols[i] = -1
+		for j, f := range fields {
+			if f.name == name {
+				r.cols[i] = j
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Read reads the next row. It returns io.EOF after the last row. A row
+// that cannot be decoded is reported as a *RowError, after which reading
+// may continue with the next row.
+func (r *CSVReader[T]) Read() (*T, error) {
+	if _, err := r.Header(); err != nil {
+		return nil, err
+	}
+	record, err := r.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return nil, csvReadError(err)
+	}
+	out := new(T)
+	rv := reflect.ValueOf(out).Elem()
+	for i, cell := range record {
+		if i >= len(r.cols) || r.cols[i] < 0 || cell == r.cfg.null {
+			continue
+		}
+		f := rv.Field(r.fields[r.cols[i]].index)
+		pv, err := parseText(f.Type(), cell)
+		if err != nil {
+			line, _ := r.r.FieldPos(i)
+			return nil, &RowError{Line: line, Column: r.header[i], Err: err}
+		}
+		f.Set(pv)
+	}
+	return out, nil
+}
+
+// ReadAll reads the remaining rows. It stops at the first error.
+func (r *CSVReader[T]) ReadAll() ([]*T, error) {
+	var out []*T
+	for {
+		v, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, v)
+	}
+}
+
+// csvReadError converts a *csv.ParseError into a *RowError.
+func csvReadError(err error) error {
+	var pe *csv.ParseError
+	if errors.As(err, &pe) {
+		return &RowError{Line: pe.Line, Err: pe.Err}
+	}
+	return err
+}
+
+// CSVWriter writes structs of type T as rows of a CSV file, preceded by a
+// header row of their column names. Nil pointers are written as the null
+// token, which is the empty cell by default. Output is buffered; call
+// Flush when done.
+type CSVWriter[T any] struct {
+	w      *csv.Writer
+	cfg    csvConfig
+	fields []csvField
+	line   int
+	record []string
+}
+
+// NewCSVWriter returns a CSVWriter that writes to w.
+func NewCSVWriter[T any](w io.Writer, opts ...CSVOption) *CSVWriter[T] {
+	cfg := csvConfig{comma: ','}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = cfg.comma
+	return &CSVWriter[T]{w: cw, cfg: cfg}
+}
+
+// Write writes v as a row, writing the header first if needed. Errors
+// are reported as a *RowError, whose Line counts the rows written.
+func (w *CSVWriter[T]) Write(v *T) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	if v == nil {
+		return &RowError{Line: w.line + 1, Err: errors.New("nil row")}
+	}
+	rv := reflect.ValueOf(v).Elem()
+	for i, f := range w.fields {
+		fv := rv.Field(f.index)
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				w.record[i] = w.cfg.null
+				continue
+			}
+			fv = fv.Elem()
+		}
+		s, err := formatScalar(fv)
+		if err != nil {
+			return &RowError{Line: w.line + 1, Column: f.name, Err: err}
+		}
+		if s == w.cfg.null && s != "" {
+			return &RowError{Line: w.line + 1, Column: f.name, Err: fmt.Errorf("value %q is the null token", s)}
+		}
+		w.record[i] = s
+	}
+	if err := w.w.Write(w.record); err != nil {
+		return err
+	}
+	w.line++
+	return nil
+}
+
+func (w *CSVWriter[T]) writeHeader() error {
+	if w.line > 0 {
+		return nil
+	}
+	fields, err := csvFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.name
+	}
+	if err := w.w.Write(header); err != nil {
+		return err
+	}
+	w.fields = fields
+	w.record = make([]string, len(fields))
+	w.line = 1
+	return nil
+}
+
+// WriteAll writes every row of vs and flushes the output. The header is
+// written even if vs is empty.
+func (w *CSVWriter[T]) WriteAll(vs []*T) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	for _, v := range vs {
+		if err := w.Write(v); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Flush writes any buffered rows to the underlying writer.
+func (w *CSVWriter[T]) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
@@ -0,0 +1,122 @@
+package pointer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type csvRow struct {
+	ID      int64      `csv:"id"`
+	Name    *string    `csv:"name"`
+	Score   *float64   `csv:"score"`
+	Seen    *time.Time `csv:"seen"`
+	Active  *bool
+	Comment string `csv:"-"`
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	seen := time.Date(2021, 5, 6, 7, 8, 9, 0, time.UTC)
+	rows := []*csvRow{
+		{ID: 1, Name: StringP("a,b"), Score: Float64P(1.5), Seen: &seen, Active: BoolP(true)},
+		{ID: 2},
+		{ID: 3, Name: StringP("")},
+	}
+	var buf bytes.Buffer
+	if err := NewCSVWriter[csvRow](&buf).WriteAll(rows); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := "id,name,score,seen,Active\n" +
+		"1,\"a,b\",1.5,2021-05-06T07:08:09Z,true\n" +
+		"2,,,,\n" +
+		"3,,,,\n"
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("Unexpected output %q", a)
+	}
+
+	out, err := NewCSVReader[csvRow](&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	rows[2].Name = nil // the empty string and nil share the empty cell
+	if !reflect.DeepEqual(rows, out) {
+		t.Errorf("Unexpected round trip %+v", out)
+	}
+}
+
+func TestCSVNullToken(t *testing.T) {
+	rows := []*csvRow{{ID: 1, Name: StringP("")}, {ID: 2}}
+	var buf bytes.Buffer
+	if err := NewCSVWriter[csvRow](&buf, WithCSVNull(`\N`), WithCSVComma(';')).WriteAll(rows); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := "id;name;score;seen;Active\n" +
+		"1;;\\N;\\N;\\N\n" +
+		"2;\\N;\\N;\\N;\\N\n"
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("Unexpected output %q", a)
+	}
+	out, err := NewCSVReader[csvRow](&buf, WithCSVNull(`\N`), WithCSVComma(';')).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(rows, out) {
+		t.Errorf("Unexpected round trip %+v", out)
+	}
+
+	w := NewCSVWriter[csvRow](io.Discard, WithCSVNull(`\N`))
+	err = w.Write(&csvRow{ID: 3, Name: StringP(`\N`)})
+	var re *RowError
+	if !errors.As(err, &re) || re.Line != 2 || re.Column != "name" {
+		t.Errorf("Expected RowError for null token value, got %v", err)
+	}
+
+	var empty bytes.Buffer
+	if err := NewCSVWriter[csvRow](&empty).WriteAll(nil); err != nil || empty.String() != "id,name,score,seen,Active\n" {
+		t.Errorf("Unexpected output %q %v", empty.String(), err)
+	}
+}
+
+func TestCSVReaderErrors(t *testing.T) {
+	in := "name,extra,score\n" +
+		"a,x,1\n" +
+		"b,y,high\n" +
+		"c,z,\n"
+	r := NewCSVReader[csvRow](strings.NewReader(in))
+	header, err := r.Header()
+	if err != nil || !reflect.DeepEqual([]string{"name", "extra", "score"}, header) {
+		t.Errorf("Unexpected header %v %v", header, err)
+	}
+	if v, err := r.Read(); err != nil || !reflect.DeepEqual(&csvRow{Name: StringP("a"), Score: Float64P(1)}, v) {
+		t.Errorf("Unexpected row %+v %v", v, err)
+	}
+	_, err = r.Read()
+	var re *RowError
+	if !errors.As(err, &re) || re.Line != 3 || re.Column != "score" {
+		t.Fatalf("Expected RowError at line 3, got %v", err)
+	}
+	if e, a := `pointer: line 3: column score: cannot parse "high" as float64: invalid syntax`, err.Error(); e != a {
+		t.Errorf("Unexpected message %s", a)
+	}
+	if v, err := r.Read(); err != nil || !reflect.DeepEqual(&csvRow{Name: StringP("c")}, v) {
+		t.Errorf("Unexpected row %+v %v", v, err)
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+
+	_, err = NewCSVReader[csvRow](strings.NewReader("name\n\"a\n")).ReadAll()
+	if !errors.As(err, &re) || re.Line != 2 {
+		t.Errorf("Expected RowError at line 2, got %v", err)
+	}
+	if _, err := NewCSVReader[struct{ M map[string]int }](strings.NewReader("M\n")).Read(); err == nil {
+		t.Errorf("Expected error for unsupported field")
+	}
+	if err := NewCSVWriter[csvRow](io.Discard).Write(nil); !errors.As(err, &re) || re.Line != 2 {
+		t.Errorf("Expected RowError for nil row, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package pointer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// SetDefaults walks the struct pointed to by v and sets every nil pointer
+// field that has a `default:"..."` tag to the parsed tag value, e.g.
+//
+//	Timeout *time.Duration `default:"30s"`
+//
+// Every type covered by this package is supported, with time.Time in
+// RFC 3339 format, as well as slices (comma-separated) and string-keyed
+// maps (comma-separated key=value pairs). Nested structs are walked
+// through pointers, slices and maps; a nil struct pointer with a default
+// tag is allocated and has its own defaults applied. A tag that cannot be
+// parsed is reported as a *FieldError naming the field path.
+func SetDefaults(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("pointer: SetDefaults requires a non-nil pointer")
+	}
+	return setDefaults(rv.Elem(), "")
+}
+
+func setDefaults(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			return setDefaults(v.Elem(), path)
+		}
+	case reflect.Interface:
+		if !v.IsNil() && v.Elem().Kind() == reflect.Ptr {
+			return setDefaults(v.Elem(), path)
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return nil
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			f := v.Field(i)
+			fp := joinPath(path, sf.Name)
+			if def, ok := sf.Tag.Lookup("default"); ok && f.Kind() == reflect.Ptr && f.IsNil() {
+				if et := f.Type().Elem(); et.Kind() == reflect.Struct && !isScalarType(et) {
+					f.Set(reflect.New(et))
+					if err := setDefaults(f.Elem(), fp); err != nil {
+						return err
+					}
+					continue
+				}
+				d, err := parseText(f.Type(), def)
+				if err != nil {
+					return &FieldError{Path: fp, Err: fmt.Errorf("invalid default: %w", err)}
+				}
+				f.Set(d)
+				continue
+			}
+			if err := setDefaults(f, fp); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := setDefaults(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if !mayHoldDefaults(v.Type().Elem()) {
+			return nil
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(iter.Value())
+			if err := setDefaults(e, fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+			v.SetMapIndex(iter.Key(), e)
+		}
+	}
+	return nil
+}
+
+// mayHoldDefaults reports whether values of t may contain struct fields.
+func mayHoldDefaults(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeType
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return mayHoldDefaults(t.Elem())
+	case reflect.Interface:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package pointer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type defaultsBackend struct {
+	Host    *string        `default:"localhost"`
+	Port    *uint16        `default:"8080"`
+	Weight  *float64       `default:"0.5"`
+	Timeout *time.Duration `default:"30s"`
+}
+
+type defaultsConfig struct {
+	Name     *string          `default:"app"`
+	Enabled  *bool            `default:"true"`
+	Replicas *int32           `default:"3"`
+	Since    *time.Time       `default:"2020-01-02T03:04:05Z"`
+	Tags     *[]string        `default:"a, b"`
+	Limits   map[string]*int  `default:"cpu=1"`
+	Primary  *defaultsBackend `default:""`
+	Backends []*defaultsBackend
+	ByName   map[string]defaultsBackend
+	Plain    string `default:"ignored"`
+	Unset    *string
+}
+
+func TestSetDefaults(t *testing.T) {
+	cfg := defaultsConfig{
+		Name:     StringP("custom"),
+		Backends: []*defaultsBackend{{Host: StringP("b1")}, nil},
+		ByName:   map[string]defaultsBackend{"x": {Port: Uint16P(1)}},
+		Limits:   map[string]*int{},
+	}
+	if err := SetDefaults(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	backend := func(host string, port uint16) *defaultsBackend {
+		return &defaultsBackend{Host: StringP(host), Port: Uint16P(port), Weight: Float64P(0.5), Timeout: DurationP(30 * time.Second)}
+	}
+	expected := defaultsConfig{
+		Name:     StringP("custom"),
+		Enabled:  BoolP(true),
+		Replicas: Int32P(3),
+		Since:    TimeP(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Tags:     &[]string{"a", "b"},
+		Limits:   map[string]*int{},
+		Primary:  backend("localhost", 8080),
+		Backends: []*defaultsBackend{backend("b1", 8080), nil},
+		ByName:   map[string]defaultsBackend{"x": *backend("localhost", 1)},
+	}
+	if !reflect.DeepEqual(expected, cfg) {
+		t.Errorf("Unexpected value %+v", cfg)
+	}
+}
+
+func TestSetDefaultsErrors(t *testing.T) {
+	var bad struct {
+		Items []struct {
+			Port *int8 `default:"300"`
+		}
+	}
+	bad.Items = make([]struct {
+		Port *int8 `default:"300"`
+	}, 1)
+	err := SetDefaults(&bad)
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Path != "Items[0].Port" {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if e, a := `pointer: Items[0].Port: invalid default: cannot parse "300" as int8: value out of range`, err.Error(); e != a {
+		t.Errorf("Unexpected message %s", a)
+	}
+
+	var when struct {
+		At *time.Time `default:"yesterday"`
+	}
+	if err := SetDefaults(&when); err == nil {
+		t.Errorf("Expected error for invalid time")
+	}
+	if err := SetDefaults(when); err == nil {
+		t.Errorf("Expected error for non-pointer value")
+	}
+}
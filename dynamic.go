@@ -0,0 +1,86 @@
+package pointer
+
+import "reflect"
+
+// PtrAny returns a pointer to a copy of v, typed as a pointer to v's
+// dynamic type. For example PtrAny(int32(3)) returns an *int32 and
+// PtrAny((*int)(nil)) returns an **int pointing to a nil *int. A nil
+// interface has no dynamic type, so PtrAny(nil) returns nil.
+func PtrAny(v any) any {
+	p := PtrValue(reflect.ValueOf(v))
+	if !p.IsValid() {
+		return nil
+	}
+	return p.Interface()
+}
+
+// DerefAny removes one pointer level from v. If v is not a pointer it is
+// returned as is. ok is false, and the result nil, if v is a nil interface
+// or a typed nil pointer.
+func DerefAny(v any) (any, bool) {
+	d, ok := DerefValue(reflect.ValueOf(v))
+	if !ok {
+		return nil, false
+	}
+	return d.Interface(), true
+}
+
+// DerefAllAny removes every pointer level from v, returning the value
+// they lead to. ok is false, and the result nil, if v is a nil interface
+// or any pointer along the way is nil.
+func DerefAllAny(v any) (any, bool) {
+	d, ok := DerefAllValue(reflect.ValueOf(v))
+	if !ok {
+		return nil, false
+	}
+	return d.Interface(), true
+}
+
+// PtrValue returns a new pointer to a copy of v. It returns the zero
+// Value if v is not valid.
+func PtrValue(v reflect.Value) reflect.Value {
+	if !v.IsValid() {
+		return reflect.Value{}
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+// DerefValue removes one pointer level from v. A v of interface kind is
+// first replaced by its dynamic value. If the value is not a pointer it is
+// returned as is. ok is false, and the result the zero Value, if v is not
+// valid or is a nil pointer or interface.
+func DerefValue(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	switch {
+	case !v.IsValid():
+		return reflect.Value{}, false
+	case v.Kind() != reflect.Ptr:
+		return v, true
+	case v.IsNil():
+		return reflect.Value{}, false
+	}
+	return v.Elem(), true
+}
+
+// DerefAllValue removes every pointer and interface level from v. ok is
+// false, and the result the zero Value, if v is not valid or a nil pointer
+// or interface is found along the way.
+func DerefAllValue(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		var ok bool
+		if v, ok = DerefValue(v); !ok {
+			return reflect.Value{}, false
+		}
+	}
+	if !v.IsValid() {
+		return reflect.Value{}, false
+	}
+	return v, true
+}
@@ -0,0 +1,82 @@
+package pointer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPtrAny(t *testing.T) {
+	v := int32(3)
+	p, ok := PtrAny(v).(*int32)
+	if !ok || *p != 3 {
+		t.Errorf("Unexpected value %v", p)
+	}
+	s := []string{"a"}
+	ps := PtrAny(s).(*[]string)
+	if !reflect.DeepEqual(*ps, s) {
+		t.Errorf("Unexpected value %v", ps)
+	}
+	pp, ok := PtrAny((*int)(nil)).(**int)
+	if !ok || pp == nil || *pp != nil {
+		t.Errorf("Unexpected value %v", pp)
+	}
+	if p := PtrAny(nil); p != nil {
+		t.Errorf("Unexpected value %v", p)
+	}
+	if p := PtrValue(reflect.Value{}); p.IsValid() {
+		t.Errorf("Unexpected value %v", p)
+	}
+}
+
+func TestDerefAny(t *testing.T) {
+	i := 5
+	pi := &i
+	cases := []struct {
+		in  any
+		one any
+		all any
+		ok  bool
+	}{
+		{in: 5, one: 5, all: 5, ok: true},
+		{in: pi, one: 5, all: 5, ok: true},
+		{in: &pi, one: pi, all: 5, ok: true},
+		{in: nil, ok: false},
+		{in: (*int)(nil), ok: false},
+	}
+	for idx, c := range cases {
+		one, ok := DerefAny(c.in)
+		if ok != c.ok || one != c.one {
+			t.Errorf("Unexpected value %v %v at idx %d", one, ok, idx)
+		}
+		all, ok := DerefAllAny(c.in)
+		if ok != c.ok || all != c.all {
+			t.Errorf("Unexpected value %v %v at idx %d", all, ok, idx)
+		}
+	}
+
+	var np *int
+	if v, ok := DerefAny(&np); !ok || v != np {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if v, ok := DerefAllAny(&np); ok || v != nil {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+}
+
+func TestDerefValue(t *testing.T) {
+	var holder struct{ V any }
+	f := reflect.ValueOf(&holder).Elem().Field(0)
+	if _, ok := DerefValue(f); ok {
+		t.Errorf("Expected nil interface to fail")
+	}
+	holder.V = Int64P(7)
+	if v, ok := DerefValue(f); !ok || v.Int() != 7 {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if v, ok := DerefAllValue(reflect.ValueOf(&holder)); !ok || v.Type() != reflect.TypeOf(holder) {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if _, ok := DerefAllValue(reflect.Value{}); ok {
+		t.Errorf("Expected invalid value to fail")
+	}
+}
@@ -0,0 +1,200 @@
+package pointer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// EmptyPolicy selects how a value that is present but empty, such as a
+// set-but-empty environment variable, is decoded into a pointer field.
+type EmptyPolicy int
+
+const (
+	// EmptyAsNil leaves the field untouched, as if the value were absent.
+	EmptyAsNil EmptyPolicy = iota
+	// EmptyAsZero sets the field to a pointer to the zero value, or to an
+	// empty slice or map.
+	EmptyAsZero
+	// EmptyAsError reports the empty value as an error.
+	EmptyAsError
+)
+
+// errEmptyValue reports a value rejected by EmptyAsError.
+var errEmptyValue = errors.New("empty value")
+
+// EnvOption configures LoadEnv.
+type EnvOption func(*envConfig)
+
+type envConfig struct {
+	lookup  func(string) (string, bool)
+	empty   EmptyPolicy
+	structs structPath
+}
+
+// WithLookup makes LoadEnv read variables through lookup instead of
+// os.LookupEnv.
+func WithLookup(lookup func(key string) (string, bool)) EnvOption {
+	return func(c *envConfig) {
+		c.lookup = lookup
+	}
+}
+
+// WithEmptyPolicy sets how LoadEnv treats set-but-empty variables. The
+// default is EmptyAsNil.
+func WithEmptyPolicy(p EmptyPolicy) EnvOption {
+	return func(c *envConfig) {
+		c.empty = p
+	}
+}
+
+// LoadEnv fills the fields of the struct pointed to by v from environment
+// variables. A field is read from the variable named by its `env:"..."`
+// tag, or else from its field path in upper snake case, so with prefix
+// "APP" the field Server.MaxConns is read from APP_SERVER_MAX_CONNS. A
+// `env:"-"` tag skips the field.
+//
+// Unset variables leave their fields untouched, so pointer fields stay
+// nil; nested struct pointers are only allocated if one of their fields is
+// set. Since the variables cannot be listed, a nested struct whose type
+// also encloses it, such as the Next field of a linked list node, is never
+// loaded, even if its variables are set. Every type covered by this package
+// is supported, with time.Time in RFC 3339 format, as well as slices of
+// comma-separated elements and string-keyed maps of comma-separated
+// key=value pairs. A variable that cannot be parsed is reported as a
+// *FieldError.
+func LoadEnv(v any, prefix string, opts ...EnvOption) error {
+	cfg := envConfig{lookup: os.LookupEnv}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("pointer: LoadEnv requires a non-nil pointer to a struct, got %T", v)
+	}
+	cfg.structs = structPath{}
+	cfg.structs.enter(rv.Elem().Type())
+	_, err := loadEnvStruct(rv.Elem(), strings.TrimSuffix(prefix, "_"), "", &cfg)
+	return err
+}
+
+func loadEnvStruct(v reflect.Value, prefix, path string, cfg *envConfig) (bool, error) {
+	set := false
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("env")
+		if tag == "-" {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = snakeCase(sf.Name)
+		}
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+		f := v.Field(i)
+		fp := joinPath(path, sf.Name)
+
+		st := f.Type()
+		if st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		if st.Kind() == reflect.Struct && !isScalarType(st) {
+			if !cfg.structs.enter(st) {
+				continue
+			}
+			ok, err := loadEnvNested(f, st, name, fp, cfg)
+			cfg.structs.leave(st)
+			if err != nil {
+				return false, err
+			}
+			set = set || ok
+			continue
+		}
+
+		s, ok := cfg.lookup(name)
+		if !ok {
+			continue
+		}
+		if s == "" {
+			switch cfg.empty {
+			case EmptyAsNil:
+				continue
+			case EmptyAsError:
+				return false, &FieldError{Path: fp, Err: fmt.Errorf("$%s: %w", name, errEmptyValue)}
+			}
+			f.Set(emptyValue(f.Type()))
+			set = true
+			continue
+		}
+		pv, err := parseText(f.Type(), s)
+		if err != nil {
+			return false, &FieldError{Path: fp, Err: fmt.Errorf("$%s: %w", name, err)}
+		}
+		f.Set(pv)
+		set = true
+	}
+	return set, nil
+}
+
+// loadEnvNested loads the struct or struct pointer f, of struct type st,
+// allocating a nil pointer only if one of its fields is set.
+func loadEnvNested(f reflect.Value, st reflect.Type, prefix, path string, cfg *envConfig) (bool, error) {
+	if f.Kind() == reflect.Struct {
+		return loadEnvStruct(f, prefix, path, cfg)
+	}
+	target := f
+	if f.IsNil() {
+		target = reflect.New(st)
+	}
+	ok, err := loadEnvStruct(target.Elem(), prefix, path, cfg)
+	if err != nil {
+		return false, err
+	}
+	if ok && f.IsNil() {
+		f.Set(target)
+	}
+	return ok, nil
+}
+
+// emptyValue returns the value EmptyAsZero stores in a field of type t: a
+// pointer to a zero value, an empty slice or map, or the zero value.
+func emptyValue(t reflect.Type) reflect.Value {
+	switch t.Kind() {
+	case reflect.Ptr:
+		p := reflect.New(t.Elem())
+		p.Elem().Set(emptyValue(t.Elem()))
+		return p
+	case reflect.Slice:
+		return reflect.MakeSlice(t, 0, 0)
+	case reflect.Map:
+		return reflect.MakeMap(t)
+	}
+	return reflect.Zero(t)
+}
+
+// snakeCase converts a Go identifier such as MaxHTTPConns into upper snake
+// case, MAX_HTTP_CONNS.
+func snakeCase(s string) string {
+	r := []rune(s)
+	var b strings.Builder
+	for i, c := range r {
+		if i > 0 && unicode.IsUpper(c) {
+			prev := r[i-1]
+			nextLower := i+1 < len(r) && unicode.IsLower(r[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(c))
+	}
+	return b.String()
+}
@@ -0,0 +1,165 @@
+package pointer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type envTLS struct {
+	CertFile *string
+	Insecure *bool
+}
+
+type envServer struct {
+	Host     *string
+	Port     *uint16 `env:"LISTEN_PORT"`
+	MaxConns *int
+	TLS      *envTLS
+}
+
+type envConfigSpec struct {
+	Name      *string
+	Debug     *bool
+	Ratio     *float64
+	Timeout   *time.Duration
+	Since     *time.Time
+	Hosts     []*string
+	Ports     *[]int32
+	Limits    map[string]*int64
+	Server    envServer
+	Backup    *envServer
+	Secret    *string `env:"-"`
+	HTTPProxy *string
+}
+
+func envLookup(vars map[string]string) EnvOption {
+	return WithLookup(func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	})
+}
+
+func TestLoadEnv(t *testing.T) {
+	vars := map[string]string{
+		"APP_NAME":                "web",
+		"APP_DEBUG":               "true",
+		"APP_TIMEOUT":             "5s",
+		"APP_SINCE":               "2020-01-02T00:00:00Z",
+		"APP_HOSTS":               "a, b",
+		"APP_PORTS":               "80,443",
+		"APP_LIMITS":              "cpu=2,mem=1024",
+		"APP_SERVER_LISTEN_PORT":  "8080",
+		"APP_SERVER_TLS_INSECURE": "false",
+		"APP_HTTP_PROXY":          "",
+		"APP_SECRET":              "nope",
+		"APP_RATIO":               "",
+	}
+	var cfg envConfigSpec
+	if err := LoadEnv(&cfg, "APP_", envLookup(vars)); err != nil {
+		t.Fatal(err)
+	}
+	expected := envConfigSpec{
+		Name:    StringP("web"),
+		Debug:   TrueP(),
+		Timeout: DurationP(5 * time.Second),
+		Since:   TimeP(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)),
+		Hosts:   []*string{StringP("a"), StringP("b")},
+		Ports:   &[]int32{80, 443},
+		Limits:  map[string]*int64{"cpu": Int64P(2), "mem": Int64P(1024)},
+		Server:  envServer{Port: Uint16P(8080), TLS: &envTLS{Insecure: FalseP()}},
+	}
+	if !reflect.DeepEqual(expected, cfg) {
+		t.Errorf("Unexpected value %+v", cfg)
+	}
+
+	var zero envConfigSpec
+	if err := LoadEnv(&zero, "APP", envLookup(vars), WithEmptyPolicy(EmptyAsZero)); err != nil {
+		t.Fatal(err)
+	}
+	if zero.HTTPProxy == nil || *zero.HTTPProxy != "" || zero.Ratio == nil || *zero.Ratio != 0 {
+		t.Errorf("Expected empty variables to set zero values")
+	}
+
+	err := LoadEnv(&zero, "APP", envLookup(vars), WithEmptyPolicy(EmptyAsError))
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Path != "Ratio" || err.Error() != "pointer: Ratio: $APP_RATIO: empty value" {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestLoadEnvErrors(t *testing.T) {
+	var cfg envConfigSpec
+	err := LoadEnv(&cfg, "", envLookup(map[string]string{"BACKUP_MAX_CONNS": "many"}))
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Path != "Backup.MaxConns" {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if e, a := `pointer: Backup.MaxConns: $BACKUP_MAX_CONNS: cannot parse "many" as int: invalid syntax`, err.Error(); e != a {
+		t.Errorf("Unexpected message %s", a)
+	}
+	if cfg.Backup != nil {
+		t.Errorf("Expected failed struct to stay nil")
+	}
+	if err := LoadEnv(cfg, ""); err == nil {
+		t.Errorf("Expected error for non-pointer value")
+	}
+}
+
+func TestLoadEnvOS(t *testing.T) {
+	t.Setenv("PTR_TEST_NAME", "os")
+	var cfg envConfigSpec
+	if err := LoadEnv(&cfg, "PTR_TEST"); err != nil {
+		t.Fatal(err)
+	}
+	if String(cfg.Name) != "os" || cfg.Debug != nil {
+		t.Errorf("Unexpected value %+v", cfg)
+	}
+}
+
+type envNode struct {
+	Name *string
+	Next *envNode
+	Edge *envEdge
+}
+
+type envEdge struct {
+	Weight *int
+	To     *envNode
+}
+
+func TestLoadEnvRecursive(t *testing.T) {
+	var n envNode
+	if err := LoadEnv(&n, "APP", WithLookup(func(string) (string, bool) { return "", false })); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if n.Next != nil || n.Edge != nil {
+		t.Errorf("Unexpected value %+v", n)
+	}
+	vars := map[string]string{"APP_NAME": "a", "APP_EDGE_WEIGHT": "2", "APP_NEXT_NAME": "b"}
+	if err := LoadEnv(&n, "APP", envLookup(vars)); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := envNode{Name: StringP("a"), Edge: &envEdge{Weight: IntP(2)}}
+	if !reflect.DeepEqual(expected, n) {
+		t.Errorf("Unexpected value %+v", n)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"Name":        "NAME",
+		"MaxConns":    "MAX_CONNS",
+		"HTTPProxy":   "HTTP_PROXY",
+		"ID":          "ID",
+		"UserID":      "USER_ID",
+		"Retry2Times": "RETRY2_TIMES",
+		"TLSCertFile": "TLS_CERT_FILE",
+	}
+	for in, out := range cases {
+		if a := snakeCase(in); a != out {
+			t.Errorf("Unexpected value %q for %q", a, in)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package pointer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError reports a failure affecting a single struct field. Path is
+// the dotted Go field path, with [index] and [key] segments for slice and
+// map elements.
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("pointer: %s: %v", e.Path, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// joinPath appends the field name to the dotted path prefix.
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// FieldErrors lists the failures of several fields, in field order.
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// err returns e as an error, or nil if it is empty.
+func (e FieldErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,166 @@
+package pointer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldMaskOf returns the dotted paths, built from JSON field names, of
+// every non-nil pointer field of the struct v. Non-nil pointers to structs
+// are descended into and contribute the paths of their own set fields, or
+// their own path if none is set. Embedded value structs are descended into
+// as well.
+func FieldMaskOf(v any) []string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	return collectFieldMask(rv, "", nil)
+}
+
+func collectFieldMask(v reflect.Value, prefix string, mask []string) []string {
+	for _, jf := range jsonFields(v.Type()) {
+		f, err := fieldByIndex(v, jf.index, false)
+		if err != nil {
+			continue
+		}
+		path := prefix + jf.name
+		switch {
+		case f.Kind() == reflect.Ptr && !f.IsNil():
+			if isMaskStruct(f.Type().Elem()) {
+				n := len(mask)
+				if mask = collectFieldMask(f.Elem(), path+".", mask); len(mask) > n {
+					continue
+				}
+			}
+			mask = append(mask, path)
+		case isMaskStruct(f.Type()):
+			mask = collectFieldMask(f, path+".", mask)
+		}
+	}
+	return mask
+}
+
+// isMaskStruct reports whether field masks may descend into values of t.
+func isMaskStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isJSONLeaf(t)
+}
+
+// ValidateFieldMask checks that every path in mask names a field of the
+// struct type of v, descending only through struct and pointer-to-struct
+// fields.
+func ValidateFieldMask(v any, mask []string) error {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("pointer: field mask requires a struct, got %T", v)
+	}
+	for _, path := range mask {
+		if _, err := resolveFieldMask(t, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveFieldMask returns the fields named by the segments of path.
+func resolveFieldMask(t reflect.Type, path string) ([]jsonField, error) {
+	segs := strings.Split(path, ".")
+	fields := make([]jsonField, len(segs))
+	for i, seg := range segs {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if !isMaskStruct(t) {
+			return nil, fmt.Errorf("pointer: invalid field mask path %q: %s is not a struct", path, strings.Join(segs[:i], "."))
+		}
+		jf, ok := lookupJSONField(t, seg)
+		if !ok {
+			return nil, fmt.Errorf("pointer: invalid field mask path %q: no field %q in %s", path, seg, t)
+		}
+		fields[i] = jf
+		t = t.FieldByIndex(jf.index).Type
+	}
+	return fields, nil
+}
+
+// ApplyFieldMask copies the fields of src named by mask into the struct
+// pointed to by dst, which must be of the same type. Nil intermediate
+// struct pointers in dst are allocated as needed. A masked field that is
+// unset in src is cleared in dst.
+func ApplyFieldMask(dst, src any, mask []string) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return errors.New("pointer: ApplyFieldMask requires a non-nil pointer")
+	}
+	dv = dv.Elem()
+	sv := reflect.ValueOf(src)
+	for sv.Kind() == reflect.Ptr && !sv.IsNil() && sv.Type() != dv.Type() {
+		sv = sv.Elem()
+	}
+	if !sv.IsValid() || sv.Type() != dv.Type() {
+		return fmt.Errorf("pointer: ApplyFieldMask requires values of the same type, got %T and %T", dst, src)
+	}
+	for _, path := range mask {
+		fields, err := resolveFieldMask(dv.Type(), path)
+		if err != nil {
+			return err
+		}
+		if err := applyFieldMaskPath(dv, sv, fields); err != nil {
+			return fmt.Errorf("pointer: field mask path %q: %v", path, err)
+		}
+	}
+	return nil
+}
+
+func applyFieldMaskPath(dv, sv reflect.Value, fields []jsonField) error {
+	for i, jf := range fields {
+		if sv.IsValid() && sv.Kind() == reflect.Ptr {
+			if sv.IsNil() {
+				sv = reflect.Value{}
+			} else {
+				sv = sv.Elem()
+			}
+		}
+		if dv.Kind() == reflect.Ptr {
+			if dv.IsNil() {
+				if !sv.IsValid() {
+					return nil
+				}
+				dv.Set(reflect.New(dv.Type().Elem()))
+			}
+			dv = dv.Elem()
+		}
+
+		if sv.IsValid() {
+			var err error
+			if sv, err = fieldByIndex(sv, jf.index, false); err != nil {
+				sv = reflect.Value{}
+			}
+		}
+		f, err := fieldByIndex(dv, jf.index, sv.IsValid())
+		switch {
+		case errors.Is(err, errNilEmbedded):
+			return nil
+		case err != nil:
+			return err
+		}
+		if i < len(fields)-1 {
+			dv = f
+			continue
+		}
+		if sv.IsValid() {
+			f.Set(deepCopy(sv))
+		} else {
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package pointer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type maskSpec struct {
+	Name     *string    `json:"name,omitempty"`
+	Replicas *int32     `json:"replicas,omitempty"`
+	Created  *time.Time `json:"created,omitempty"`
+	Meta     *patchMeta `json:"meta,omitempty"`
+	Empty    *patchMeta `json:"empty,omitempty"`
+	Item     patchItem  `json:"item"`
+	Tags     []string   `json:"tags,omitempty"`
+}
+
+func TestFieldMaskOf(t *testing.T) {
+	spec := maskSpec{
+		Name:    StringP("a"),
+		Created: TimeP(time.Unix(0, 0)),
+		Meta:    &patchMeta{Owner: StringP("me")},
+		Empty:   &patchMeta{},
+		Item:    patchItem{Count: Int64P(1)},
+		Tags:    []string{"x"},
+	}
+	expected := []string{"name", "created", "meta.owner", "empty", "item.count"}
+	if e, a := expected, FieldMaskOf(&spec); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected mask %v", a)
+	}
+	if a := FieldMaskOf(maskSpec{}); len(a) != 0 {
+		t.Errorf("Unexpected mask %v", a)
+	}
+	if a := FieldMaskOf(3); a != nil {
+		t.Errorf("Unexpected mask %v", a)
+	}
+}
+
+func TestValidateFieldMask(t *testing.T) {
+	if err := ValidateFieldMask(maskSpec{}, []string{"name", "meta.owner", "item.id", "created"}); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	cases := map[string]string{
+		"nope":         `pointer: invalid field mask path "nope": no field "nope" in pointer.maskSpec`,
+		"meta.nope":    `pointer: invalid field mask path "meta.nope": no field "nope" in pointer.patchMeta`,
+		"name.x":       `pointer: invalid field mask path "name.x": name is not a struct`,
+		"created.year": `pointer: invalid field mask path "created.year": created is not a struct`,
+		"":             `pointer: invalid field mask path "": no field "" in pointer.maskSpec`,
+	}
+	for path, msg := range cases {
+		err := ValidateFieldMask(&maskSpec{}, []string{path})
+		if err == nil || err.Error() != msg {
+			t.Errorf("Unexpected error for %q: %v", path, err)
+		}
+	}
+}
+
+func TestApplyFieldMask(t *testing.T) {
+	src := maskSpec{
+		Name:     StringP("b"),
+		Replicas: Int32P(3),
+		Meta:     &patchMeta{Owner: StringP("me"), Labels: map[string]string{"a": "1"}},
+	}
+	dst := maskSpec{
+		Name:  StringP("a"),
+		Empty: &patchMeta{Owner: StringP("old")},
+		Item:  patchItem{ID: StringP("x")},
+	}
+	err := ApplyFieldMask(&dst, src, []string{"replicas", "meta.owner", "empty.owner", "item.id", "item.count", "empty.labels"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ApplyFieldMask(&dst, maskSpec{}, []string{"created", "meta.labels"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := maskSpec{
+		Name:     StringP("a"),
+		Replicas: Int32P(3),
+		Meta:     &patchMeta{Owner: StringP("me")},
+		Empty:    &patchMeta{},
+		Item:     patchItem{},
+	}
+	if !reflect.DeepEqual(expected, dst) {
+		t.Errorf("Unexpected value %+v", dst)
+	}
+	if dst.Replicas == src.Replicas {
+		t.Errorf("Expected masked pointer to be copied")
+	}
+
+	if err := ApplyFieldMask(&dst, &src, []string{"meta.nope"}); err == nil {
+		t.Errorf("Expected error for invalid mask")
+	}
+	if err := ApplyFieldMask(&dst, patchItem{}, nil); err == nil {
+		t.Errorf("Expected error for mismatched types")
+	}
+	if err := ApplyFieldMask(dst, src, nil); err == nil {
+		t.Errorf("Expected error for non-pointer destination")
+	}
+}
+
+func TestApplyFieldMaskUnexportedEmbedded(t *testing.T) {
+	var v patchOuter
+	src := patchOuter{patchInner: &patchInner{X: IntP(1)}}
+	if err := ApplyFieldMask(&v, &src, []string{"x"}); err == nil {
+		t.Errorf("Expected error for unexported embedded pointer")
+	}
+	if v.patchInner != nil {
+		t.Errorf("Unexpected allocation %+v", v)
+	}
+	if err := ApplyFieldMask(&v, &patchOuter{}, []string{"x"}); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
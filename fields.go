@@ -0,0 +1,196 @@
+package pointer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// jsonField describes a struct field the way encoding/json sees it.
+type jsonField struct {
+	name  string
+	index []int
+}
+
+var jsonFieldCache sync.Map // map[reflect.Type][]jsonField
+
+// jsonFields returns the JSON-visible fields of struct type t. Untagged
+// embedded structs are flattened into their parent, and fields tagged
+// `json:"-"` or unexported are skipped. As in encoding/json, embedded
+// structs are walked breadth-first, visiting each type once, so a
+// shallower field hides deeper ones of the same name. Of several fields
+// with a name at the same depth, a sole tagged one wins; otherwise the
+// name is dropped.
+func jsonFields(t reflect.Type) []jsonField {
+	if f, ok := jsonFieldCache.Load(t); ok {
+		return f.([]jsonField)
+	}
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	type candidate struct {
+		jsonField
+		tagged bool
+	}
+	var fields []jsonField
+	seen := map[string]bool{}
+	visited := map[reflect.Type]bool{}
+	next := []embedded{{typ: t}}
+	for len(next) > 0 {
+		current := next
+		next = nil
+		count := map[reflect.Type]int{}
+		for _, e := range current {
+			count[e.typ]++
+		}
+		var names []string
+		found := map[string][]candidate{}
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				name, ok := jsonName(sf)
+				if !ok {
+					continue
+				}
+				index := append(e.index[:len(e.index):len(e.index)], i)
+				if sf.Anonymous && name == "" {
+					ft := sf.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct {
+						next = append(next, embedded{typ: ft, index: index})
+						continue
+					}
+				}
+				if !sf.IsExported() {
+					continue
+				}
+				c := candidate{jsonField: jsonField{name: name, index: index}, tagged: name != ""}
+				if !c.tagged {
+					c.name = sf.Name
+				}
+				if seen[c.name] {
+					continue
+				}
+				if found[c.name] == nil {
+					names = append(names, c.name)
+				}
+				found[c.name] = append(found[c.name], c)
+				if count[e.typ] > 1 {
+					// A type embedded twice at this depth makes its
+					// fields ambiguous.
+					found[c.name] = append(found[c.name], c)
+				}
+			}
+		}
+		for _, name := range names {
+			seen[name] = true
+			cs := found[name]
+			if len(cs) > 1 {
+				var tagged []candidate
+				for _, c := range cs {
+					if c.tagged {
+						tagged = append(tagged, c)
+					}
+				}
+				cs = tagged
+			}
+			if len(cs) == 1 {
+				fields = append(fields, cs[0].jsonField)
+			}
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	jsonFieldCache.Store(t, fields)
+	return fields
+}
+
+// jsonName returns the name from the json tag of sf, or "" if the tag
+// does not rename the field. ok is false if the field is skipped.
+func jsonName(sf reflect.StructField) (name string, ok bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if i := strings.IndexByte(tag, ','); i >= 0 {
+		tag = tag[:i]
+	}
+	if !sf.Anonymous && !sf.IsExported() {
+		return "", false
+	}
+	return tag, true
+}
+
+// lookupJSONField returns the field of struct type t named name in JSON.
+func lookupJSONField(t reflect.Type, name string) (jsonField, bool) {
+	for _, f := range jsonFields(t) {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
+
+// structPath holds the struct types enclosing the current position of a
+// walk that descends into nested structs, so that the walk can stop at a
+// type that contains itself, such as a linked list node.
+type structPath map[reflect.Type]bool
+
+// enter adds t to p. It reports false, leaving p unchanged, if t already
+// encloses the current position.
+func (p structPath) enter(t reflect.Type) bool {
+	if p[t] {
+		return false
+	}
+	p[t] = true
+	return true
+}
+
+// leave removes t from p.
+func (p structPath) leave(t reflect.Type) {
+	delete(p, t)
+}
+
+// errNilEmbedded is returned by fieldByIndex for a nil embedded struct
+// pointer that it is not allowed to allocate.
+var errNilEmbedded = errors.New("nil embedded struct pointer")
+
+// fieldByIndex returns the nested field of v at index. Nil embedded struct
+// pointers along the way are allocated when alloc is true; otherwise
+// errNilEmbedded is returned if one is encountered. Like encoding/json, it
+// cannot allocate an embedded pointer to an unexported struct.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}, errNilEmbedded
+				}
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
@@ -0,0 +1,84 @@
+package pointer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type fieldsSelf struct {
+	*fieldsSelf
+	A *int `json:"a,omitempty"`
+}
+
+type fieldsLeft struct {
+	V *int `json:"Y,omitempty"`
+	W *int `json:",omitempty"`
+	Z *int
+}
+
+type fieldsRight struct {
+	Y *int `json:",omitempty"`
+	W *int `json:",omitempty"`
+	Z *int
+}
+
+type fieldsDeep struct {
+	fieldsLeft
+}
+
+type fieldsMixed struct {
+	fieldsLeft
+	fieldsRight
+	*fieldsDeep
+	Z *int
+}
+
+func TestJSONFields(t *testing.T) {
+	names := func(v any) []string {
+		var out []string
+		for _, f := range jsonFields(reflect.TypeOf(v)) {
+			out = append(out, f.name)
+		}
+		return out
+	}
+	if e, a := []string{"a"}, names(fieldsSelf{}); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected fields %v", a)
+	}
+
+	// The shallower Z hides the embedded ones, the tagged Y wins over the
+	// untagged one, and the two untagged W at the same depth cancel out,
+	// as for encoding/json.
+	v := fieldsMixed{
+		fieldsLeft:  fieldsLeft{V: IntP(1), W: IntP(2)},
+		fieldsRight: fieldsRight{Y: IntP(3), W: IntP(4)},
+		Z:           IntP(5),
+	}
+	if e, a := []string{"Y", "Z"}, names(v); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected fields %v", a)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if e, a := `{"Y":1,"Z":5}`, string(b); e != a {
+		t.Errorf("Unexpected encoding/json output %s", a)
+	}
+}
+
+func TestSelfEmbeddedPointer(t *testing.T) {
+	v := fieldsSelf{A: IntP(1)}
+	if e, a := []string{"a"}, FieldMaskOf(v); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected mask %v", a)
+	}
+	if err := ApplyMergePatch(&v, []byte(`{"a":2}`)); err != nil || Int(v.A) != 2 {
+		t.Errorf("Unexpected result %+v %v", v, err)
+	}
+	ops, err := GenerateJSONPatch(fieldsSelf{}, v)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if e := []Operation{{Op: "add", Path: "/a", Value: 2}}; !reflect.DeepEqual(e, ops) {
+		t.Errorf("Unexpected patch %+v", ops)
+	}
+}
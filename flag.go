@@ -0,0 +1,463 @@
+package pointer
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ptrValue is a flag.Value that allocates the pointer it fills only when
+// the flag is given, so an unset flag can be told apart from a zero one.
+type ptrValue[T any] struct {
+	p      **T
+	parse  func(string) (T, error)
+	isBool bool
+}
+
+func (v *ptrValue[T]) Set(s string) error {
+	x, err := v.parse(s)
+	if err != nil {
+		return err
+	}
+	*v.p = &x
+	return nil
+}
+
+func (v *ptrValue[T]) String() string {
+	if v == nil || v.p == nil || *v.p == nil {
+		return ""
+	}
+	return formatFlag(**v.p)
+}
+
+func (v *ptrValue[T]) IsBoolFlag() bool {
+	return v.isBool
+}
+
+// ptrSliceValue is a flag.Value that appends a new pointer to the slice it
+// fills every time the flag is given.
+type ptrSliceValue[T any] struct {
+	p     *[]*T
+	parse func(string) (T, error)
+}
+
+func (v *ptrSliceValue[T]) Set(s string) error {
+	x, err := v.parse(s)
+	if err != nil {
+		return err
+	}
+	*v.p = append(*v.p, &x)
+	return nil
+}
+
+func (v *ptrSliceValue[T]) String() string {
+	if v == nil || v.p == nil {
+		return ""
+	}
+	parts := make([]string, len(*v.p))
+	for i, x := range *v.p {
+		if x != nil {
+			parts[i] = formatFlag(*x)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ptrMapValue is a flag.Value that sets a key of the map it fills every
+// time the flag is given as key=value.
+type ptrMapValue[T any] struct {
+	p     *map[string]*T
+	parse func(string) (T, error)
+}
+
+func (v *ptrMapValue[T]) Set(s string) error {
+	k, val, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("expected key=value, got %q", s)
+	}
+	x, err := v.parse(val)
+	if err != nil {
+		return err
+	}
+	if *v.p == nil {
+		*v.p = make(map[string]*T)
+	}
+	(*v.p)[k] = &x
+	return nil
+}
+
+func (v *ptrMapValue[T]) String() string {
+	if v == nil || v.p == nil {
+		return ""
+	}
+	keys := SortedKeys(*v.p)
+	for i, k := range keys {
+		if x := (*v.p)[k]; x != nil {
+			keys[i] = k + "=" + formatFlag(*x)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+func formatFlag(v any) string {
+	if t, ok := v.(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+func parseTimeRFC3339(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+func flagSet(fs *flag.FlagSet) *flag.FlagSet {
+	if fs == nil {
+		return flag.CommandLine
+	}
+	return fs
+}
+
+// StringPVar defines a string flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func StringPVar(fs *flag.FlagSet, p **string, name, usage string) {
+	flagSet(fs).Var(&ptrValue[string]{p: p, parse: parseString}, name, usage)
+}
+
+// StringPSliceVar defines a repeatable string flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func StringPSliceVar(fs *flag.FlagSet, p *[]*string, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[string]{p: p, parse: parseString}, name, usage)
+}
+
+// StringPMapVar defines a repeatable key=value string flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func StringPMapVar(fs *flag.FlagSet, p *map[string]*string, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[string]{p: p, parse: parseString}, name, usage)
+}
+
+// BoolPVar defines a bool flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func BoolPVar(fs *flag.FlagSet, p **bool, name, usage string) {
+	flagSet(fs).Var(&ptrValue[bool]{p: p, parse: strconv.ParseBool, isBool: true}, name, usage)
+}
+
+// BoolPSliceVar defines a repeatable bool flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func BoolPSliceVar(fs *flag.FlagSet, p *[]*bool, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[bool]{p: p, parse: strconv.ParseBool}, name, usage)
+}
+
+// BoolPMapVar defines a repeatable key=value bool flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func BoolPMapVar(fs *flag.FlagSet, p *map[string]*bool, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[bool]{p: p, parse: strconv.ParseBool}, name, usage)
+}
+
+// IntPVar defines an int flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func IntPVar(fs *flag.FlagSet, p **int, name, usage string) {
+	flagSet(fs).Var(&ptrValue[int]{p: p, parse: strconv.Atoi}, name, usage)
+}
+
+// IntPSliceVar defines a repeatable int flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func IntPSliceVar(fs *flag.FlagSet, p *[]*int, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[int]{p: p, parse: strconv.Atoi}, name, usage)
+}
+
+// IntPMapVar defines a repeatable key=value int flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func IntPMapVar(fs *flag.FlagSet, p *map[string]*int, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[int]{p: p, parse: strconv.Atoi}, name, usage)
+}
+
+// Int8PVar defines an int8 flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func Int8PVar(fs *flag.FlagSet, p **int8, name, usage string) {
+	flagSet(fs).Var(&ptrValue[int8]{p: p, parse: parseInt8}, name, usage)
+}
+
+// Int8PSliceVar defines a repeatable int8 flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func Int8PSliceVar(fs *flag.FlagSet, p *[]*int8, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[int8]{p: p, parse: parseInt8}, name, usage)
+}
+
+// Int8PMapVar defines a repeatable key=value int8 flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func Int8PMapVar(fs *flag.FlagSet, p *map[string]*int8, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[int8]{p: p, parse: parseInt8}, name, usage)
+}
+
+// Int16PVar defines an int16 flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func Int16PVar(fs *flag.FlagSet, p **int16, name, usage string) {
+	flagSet(fs).Var(&ptrValue[int16]{p: p, parse: parseInt16}, name, usage)
+}
+
+// Int16PSliceVar defines a repeatable int16 flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func Int16PSliceVar(fs *flag.FlagSet, p *[]*int16, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[int16]{p: p, parse: parseInt16}, name, usage)
+}
+
+// Int16PMapVar defines a repeatable key=value int16 flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func Int16PMapVar(fs *flag.FlagSet, p *map[string]*int16, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[int16]{p: p, parse: parseInt16}, name, usage)
+}
+
+// Int32PVar defines an int32 flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func Int32PVar(fs *flag.FlagSet, p **int32, name, usage string) {
+	flagSet(fs).Var(&ptrValue[int32]{p: p, parse: parseInt32}, name, usage)
+}
+
+// Int32PSliceVar defines a repeatable int32 flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func Int32PSliceVar(fs *flag.FlagSet, p *[]*int32, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[int32]{p: p, parse: parseInt32}, name, usage)
+}
+
+// Int32PMapVar defines a repeatable key=value int32 flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func Int32PMapVar(fs *flag.FlagSet, p *map[string]*int32, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[int32]{p: p, parse: parseInt32}, name, usage)
+}
+
+// Int64PVar defines an int64 flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func Int64PVar(fs *flag.FlagSet, p **int64, name, usage string) {
+	flagSet(fs).Var(&ptrValue[int64]{p: p, parse: parseInt64}, name, usage)
+}
+
+// Int64PSliceVar defines a repeatable int64 flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func Int64PSliceVar(fs *flag.FlagSet, p *[]*int64, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[int64]{p: p, parse: parseInt64}, name, usage)
+}
+
+// Int64PMapVar defines a repeatable key=value int64 flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func Int64PMapVar(fs *flag.FlagSet, p *map[string]*int64, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[int64]{p: p, parse: parseInt64}, name, usage)
+}
+
+// UintPVar defines a uint flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func UintPVar(fs *flag.FlagSet, p **uint, name, usage string) {
+	flagSet(fs).Var(&ptrValue[uint]{p: p, parse: parseUint}, name, usage)
+}
+
+// UintPSliceVar defines a repeatable uint flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func UintPSliceVar(fs *flag.FlagSet, p *[]*uint, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[uint]{p: p, parse: parseUint}, name, usage)
+}
+
+// UintPMapVar defines a repeatable key=value uint flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func UintPMapVar(fs *flag.FlagSet, p *map[string]*uint, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[uint]{p: p, parse: parseUint}, name, usage)
+}
+
+// Uint8PVar defines a uint8 flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func Uint8PVar(fs *flag.FlagSet, p **uint8, name, usage string) {
+	flagSet(fs).Var(&ptrValue[uint8]{p: p, parse: parseUint8}, name, usage)
+}
+
+// Uint8PSliceVar defines a repeatable uint8 flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func Uint8PSliceVar(fs *flag.FlagSet, p *[]*uint8, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[uint8]{p: p, parse: parseUint8}, name, usage)
+}
+
+// Uint8PMapVar defines a repeatable key=value uint8 flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func Uint8PMapVar(fs *flag.FlagSet, p *map[string]*uint8, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[uint8]{p: p, parse: parseUint8}, name, usage)
+}
+
+// Uint16PVar defines a uint16 flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func Uint16PVar(fs *flag.FlagSet, p **uint16, name, usage string) {
+	flagSet(fs).Var(&ptrValue[uint16]{p: p, parse: parseUint16}, name, usage)
+}
+
+// Uint16PSliceVar defines a repeatable uint16 flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func Uint16PSliceVar(fs *flag.FlagSet, p *[]*uint16, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[uint16]{p: p, parse: parseUint16}, name, usage)
+}
+
+// Uint16PMapVar defines a repeatable key=value uint16 flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func Uint16PMapVar(fs *flag.FlagSet, p *map[string]*uint16, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[uint16]{p: p, parse: parseUint16}, name, usage)
+}
+
+// Uint32PVar defines a uint32 flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func Uint32PVar(fs *flag.FlagSet, p **uint32, name, usage string) {
+	flagSet(fs).Var(&ptrValue[uint32]{p: p, parse: parseUint32}, name, usage)
+}
+
+// Uint32PSliceVar defines a repeatable uint32 flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func Uint32PSliceVar(fs *flag.FlagSet, p *[]*uint32, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[uint32]{p: p, parse: parseUint32}, name, usage)
+}
+
+// Uint32PMapVar defines a repeatable key=value uint32 flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func Uint32PMapVar(fs *flag.FlagSet, p *map[string]*uint32, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[uint32]{p: p, parse: parseUint32}, name, usage)
+}
+
+// Uint64PVar defines a uint64 flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func Uint64PVar(fs *flag.FlagSet, p **uint64, name, usage string) {
+	flagSet(fs).Var(&ptrValue[uint64]{p: p, parse: parseUint64}, name, usage)
+}
+
+// Uint64PSliceVar defines a repeatable uint64 flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func Uint64PSliceVar(fs *flag.FlagSet, p *[]*uint64, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[uint64]{p: p, parse: parseUint64}, name, usage)
+}
+
+// Uint64PMapVar defines a repeatable key=value uint64 flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func Uint64PMapVar(fs *flag.FlagSet, p *map[string]*uint64, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[uint64]{p: p, parse: parseUint64}, name, usage)
+}
+
+// Float32PVar defines a float32 flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func Float32PVar(fs *flag.FlagSet, p **float32, name, usage string) {
+	flagSet(fs).Var(&ptrValue[float32]{p: p, parse: parseFloat32}, name, usage)
+}
+
+// Float32PSliceVar defines a repeatable float32 flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func Float32PSliceVar(fs *flag.FlagSet, p *[]*float32, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[float32]{p: p, parse: parseFloat32}, name, usage)
+}
+
+// Float32PMapVar defines a repeatable key=value float32 flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func Float32PMapVar(fs *flag.FlagSet, p *map[string]*float32, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[float32]{p: p, parse: parseFloat32}, name, usage)
+}
+
+// Float64PVar defines a float64 flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+func Float64PVar(fs *flag.FlagSet, p **float64, name, usage string) {
+	flagSet(fs).Var(&ptrValue[float64]{p: p, parse: parseFloat64}, name, usage)
+}
+
+// Float64PSliceVar defines a repeatable float64 flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func Float64PSliceVar(fs *flag.FlagSet, p *[]*float64, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[float64]{p: p, parse: parseFloat64}, name, usage)
+}
+
+// Float64PMapVar defines a repeatable key=value float64 flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func Float64PMapVar(fs *flag.FlagSet, p *map[string]*float64, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[float64]{p: p, parse: parseFloat64}, name, usage)
+}
+
+// DurationPVar defines a time.Duration flag with the given name and usage
+// on fs, or on flag.CommandLine if fs is nil. *p is left untouched unless
+// the flag is given, in which case it is set to a pointer to the parsed
+// value.
+func DurationPVar(fs *flag.FlagSet, p **time.Duration, name, usage string) {
+	flagSet(fs).Var(&ptrValue[time.Duration]{p: p, parse: time.ParseDuration}, name, usage)
+}
+
+// DurationPSliceVar defines a repeatable time.Duration flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag appends a pointer to the parsed value to *p.
+func DurationPSliceVar(fs *flag.FlagSet, p *[]*time.Duration, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[time.Duration]{p: p, parse: time.ParseDuration}, name, usage)
+}
+
+// DurationPMapVar defines a repeatable key=value time.Duration flag with
+// the given name and usage on fs, or on flag.CommandLine if fs is nil.
+// Every occurrence of the flag sets a key of *p, allocating the map if
+// needed.
+func DurationPMapVar(fs *flag.FlagSet, p *map[string]*time.Duration, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[time.Duration]{p: p, parse: time.ParseDuration}, name, usage)
+}
+
+// TimePVar defines a time.Time flag with the given name and usage on fs, or
+// on flag.CommandLine if fs is nil. *p is left untouched unless the flag
+// is given, in which case it is set to a pointer to the parsed value.
+// Times are parsed as RFC 3339.
+func TimePVar(fs *flag.FlagSet, p **time.Time, name, usage string) {
+	flagSet(fs).Var(&ptrValue[time.Time]{p: p, parse: parseTimeRFC3339}, name, usage)
+}
+
+// TimePSliceVar defines a repeatable time.Time flag with the given name and
+// usage on fs, or on flag.CommandLine if fs is nil. Every occurrence of
+// the flag appends a pointer to the parsed value to *p.
+func TimePSliceVar(fs *flag.FlagSet, p *[]*time.Time, name, usage string) {
+	flagSet(fs).Var(&ptrSliceValue[time.Time]{p: p, parse: parseTimeRFC3339}, name, usage)
+}
+
+// TimePMapVar defines a repeatable key=value time.Time flag with the given
+// name and usage on fs, or on flag.CommandLine if fs is nil. Every
+// occurrence of the flag sets a key of *p, allocating the map if needed.
+func TimePMapVar(fs *flag.FlagSet, p *map[string]*time.Time, name, usage string) {
+	flagSet(fs).Var(&ptrMapValue[time.Time]{p: p, parse: parseTimeRFC3339}, name, usage)
+}
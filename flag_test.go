@@ -0,0 +1,120 @@
+package pointer
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPVar(t *testing.T) {
+	var (
+		replicas *int
+		name     *string
+		verbose  *bool
+		ratio    *float32
+		timeout  *time.Duration
+		since    *time.Time
+		limit    *uint16
+	)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	IntPVar(fs, &replicas, "replicas", "")
+	StringPVar(fs, &name, "name", "")
+	BoolPVar(fs, &verbose, "verbose", "")
+	Float32PVar(fs, &ratio, "ratio", "")
+	DurationPVar(fs, &timeout, "timeout", "")
+	TimePVar(fs, &since, "since", "")
+	Uint16PVar(fs, &limit, "limit", "")
+
+	err := fs.Parse([]string{"--replicas=0", "--name", "", "--verbose", "--timeout=1m", "--since=2020-01-02T00:00:00Z"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replicas == nil || *replicas != 0 {
+		t.Errorf("Unexpected replicas %v", replicas)
+	}
+	if name == nil || *name != "" {
+		t.Errorf("Unexpected name %v", name)
+	}
+	if verbose == nil || !*verbose {
+		t.Errorf("Unexpected verbose %v", verbose)
+	}
+	if timeout == nil || *timeout != time.Minute {
+		t.Errorf("Unexpected timeout %v", timeout)
+	}
+	if since == nil || since.Year() != 2020 {
+		t.Errorf("Unexpected since %v", since)
+	}
+	if ratio != nil || limit != nil {
+		t.Errorf("Expected unset flags to stay nil")
+	}
+	if e, a := "1m0s", fs.Lookup("timeout").Value.String(); e != a {
+		t.Errorf("Unexpected string %q", a)
+	}
+	if e, a := "", fs.Lookup("ratio").Value.String(); e != a {
+		t.Errorf("Unexpected string %q", a)
+	}
+}
+
+func TestPVarErrors(t *testing.T) {
+	var limit *uint8
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	Uint8PVar(fs, &limit, "limit", "")
+	if err := fs.Parse([]string{"--limit=256"}); err == nil {
+		t.Errorf("Expected error for out of range value")
+	}
+	if limit != nil {
+		t.Errorf("Expected failed flag to stay nil")
+	}
+}
+
+func TestPSliceVar(t *testing.T) {
+	var ports []*int32
+	var tags map[string]*string
+	var weights map[string]*float64
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	Int32PSliceVar(fs, &ports, "port", "")
+	StringPMapVar(fs, &tags, "tag", "")
+	Float64PMapVar(fs, &weights, "weight", "")
+
+	err := fs.Parse([]string{"--port=80", "--port", "443", "--tag", "env=prod", "--tag=team=a=b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, a := []int32{80, 443}, Int32Slice(ports); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected ports %v", a)
+	}
+	if e, a := map[string]string{"env": "prod", "team": "a=b"}, StringMap(tags); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected tags %v", a)
+	}
+	if weights != nil {
+		t.Errorf("Expected unset map flag to stay nil")
+	}
+	if e, a := "80,443", fs.Lookup("port").Value.String(); e != a {
+		t.Errorf("Unexpected string %q", a)
+	}
+	if e, a := "env=prod,team=a=b", fs.Lookup("tag").Value.String(); e != a {
+		t.Errorf("Unexpected string %q", a)
+	}
+	if err := fs.Parse([]string{"--weight=x"}); err == nil {
+		t.Errorf("Expected error for malformed map flag")
+	}
+}
+
+func TestPVarDefaults(t *testing.T) {
+	var replicas *int64
+	var hosts []*string
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	Int64PVar(fs, &replicas, "replicas", "number of replicas")
+	StringPSliceVar(fs, &hosts, "host", "host to contact")
+	var b strings.Builder
+	fs.SetOutput(&b)
+	fs.PrintDefaults()
+	if !strings.Contains(b.String(), "number of replicas") || strings.Contains(b.String(), "default") {
+		t.Errorf("Unexpected usage %q", b.String())
+	}
+}
@@ -0,0 +1,39 @@
+package pointer
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// maxFormMemory is the part of a multipart body DecodeForm keeps in
+// memory; larger file parts are stored in temporary files.
+const maxFormMemory = 32 << 20
+
+// DecodeForm parses the application/x-www-form-urlencoded or
+// multipart/form-data body of r and decodes its values into the struct
+// pointed to by v, as DecodeQuery does. Parameters in the URL are not
+// read. Missing inputs, such as unchecked checkboxes, leave their fields
+// nil, and a checkbox submitted with the default value "on" decodes as
+// true into a bool field.
+//
+// A body that cannot be parsed is reported as a plain error; fields that
+// fail to decode are reported together as a FieldErrors.
+func DecodeForm(r *http.Request, v any, opts ...QueryOption) error {
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var err error
+	if ct == "multipart/form-data" {
+		err = r.ParseMultipartForm(maxFormMemory)
+	} else {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		return fmt.Errorf("pointer: cannot parse form: %w", err)
+	}
+	return DecodeQuery(r.PostForm, v, append([]QueryOption{withCheckboxes}, opts...)...)
+}
+
+// withCheckboxes makes DecodeQuery read "on" as true for bool fields.
+func withCheckboxes(c *queryConfig) {
+	c.checkbox = true
+}
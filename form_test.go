@@ -0,0 +1,99 @@
+package pointer
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type formSpec struct {
+	Name      *string   `query:"name"`
+	Age       *int      `query:"age"`
+	Subscribe *bool     `query:"subscribe"`
+	Terms     *bool     `query:"terms"`
+	Colors    []*string `query:"color"`
+}
+
+func TestDecodeFormURLEncoded(t *testing.T) {
+	body := strings.NewReader("name=Ann&subscribe=on&color=red&color=blue&age=")
+	r := httptest.NewRequest(http.MethodPost, "/?age=3", body)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var out formSpec
+	if err := DecodeForm(r, &out); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := formSpec{
+		Name:      StringP("Ann"),
+		Subscribe: BoolP(true),
+		Colors:    []*string{StringP("red"), StringP("blue")},
+	}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Unexpected value %+v", out)
+	}
+}
+
+func TestDecodeFormMultipart(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	w.WriteField("name", "Bob")
+	w.WriteField("age", "41")
+	w.WriteField("terms", "false")
+	fw, _ := w.CreateFormFile("avatar", "a.png")
+	fw.Write([]byte("png"))
+	w.Close()
+	r := httptest.NewRequest(http.MethodPost, "/", &buf)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	var out formSpec
+	if err := DecodeForm(r, &out); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := formSpec{Name: StringP("Bob"), Age: IntP(41), Terms: BoolP(false)}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Unexpected value %+v", out)
+	}
+}
+
+func TestDecodeFormErrors(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("age=old&terms=yes&name=x"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var out formSpec
+	err := DecodeForm(r, &out)
+	var fes FieldErrors
+	if !errors.As(err, &fes) || len(fes) != 2 || fes[0].Path != "Age" || fes[1].Path != "Terms" {
+		t.Errorf("Expected errors for Age and Terms, got %v", err)
+	}
+	if !reflect.DeepEqual(StringP("x"), out.Name) {
+		t.Errorf("Unexpected value %v", out.Name)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("x"))
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=zz")
+	if err := DecodeForm(r, &out); err == nil || errors.As(err, &fes) {
+		t.Errorf("Expected parse error, got %v", err)
+	}
+}
+
+type formNode struct {
+	Name     *string `query:"name"`
+	Parent   *formNode
+	Children []formNode
+}
+
+func TestDecodeFormRecursive(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=root&Parent.name=up"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var out formNode
+	if err := DecodeForm(r, &out); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if e := (formNode{Name: StringP("root"), Parent: &formNode{Name: StringP("up")}}); !reflect.DeepEqual(e, out) {
+		t.Errorf("Unexpected value %+v", out)
+	}
+}
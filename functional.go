@@ -0,0 +1,73 @@
+package pointer
+
+// Map returns a pointer to f applied to the value p points to, or nil if p
+// is nil. f is not called for a nil p.
+func Map[T, U any](p *T, f func(T) U) *U {
+	if p == nil {
+		return nil
+	}
+	u := f(*p)
+	return &u
+}
+
+// FlatMap returns f applied to the value p points to, or nil if p is nil.
+func FlatMap[T, U any](p *T, f func(T) *U) *U {
+	if p == nil {
+		return nil
+	}
+	return f(*p)
+}
+
+// Filter returns p if it is non-nil and its value satisfies pred, and nil
+// otherwise.
+func Filter[T any](p *T, pred func(T) bool) *T {
+	if p == nil || !pred(*p) {
+		return nil
+	}
+	return p
+}
+
+// Coalesce returns the first non-nil pointer passed in, or nil if there
+// is none.
+func Coalesce[T any](ps ...*T) *T {
+	for _, p := range ps {
+		if p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+// Or returns p if it is non-nil, and fallback otherwise.
+func Or[T any](p *T, fallback *T) *T {
+	if p != nil {
+		return p
+	}
+	return fallback
+}
+
+// MapSlice applies Map to every element of src, so nil elements stay nil.
+func MapSlice[T, U any](src []*T, f func(T) U) []*U {
+	dst := make([]*U, len(src))
+	for i := 0; i < len(src); i++ {
+		dst[i] = Map(src[i], f)
+	}
+	return dst
+}
+
+// CompactSlice returns the non-nil elements of src, in order.
+func CompactSlice[T any](src []*T) []*T {
+	n := 0
+	for _, p := range src {
+		if p != nil {
+			n++
+		}
+	}
+	dst := make([]*T, 0, n)
+	for _, p := range src {
+		if p != nil {
+			dst = append(dst, p)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,139 @@
+package pointer
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	if a := Map(IntP(2), strconv.Itoa); a == nil || *a != "2" {
+		t.Errorf("Unexpected value %v", a)
+	}
+	called := false
+	if a := Map(nil, func(int) string { called = true; return "" }); a != nil || called {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	parse := func(s string) *int {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil
+		}
+		return &n
+	}
+	if a := FlatMap(StringP("3"), parse); a == nil || *a != 3 {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if a := FlatMap(StringP("x"), parse); a != nil {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if a := FlatMap(nil, parse); a != nil {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	positive := func(n int) bool { return n > 0 }
+	p := IntP(1)
+	if a := Filter(p, positive); a != p {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if a := Filter(IntP(-1), positive); a != nil {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if a := Filter(nil, positive); a != nil {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	a, b := StringP("a"), StringP("b")
+	if v := Coalesce(nil, a, b); v != a {
+		t.Errorf("Unexpected value %v", v)
+	}
+	if v := Coalesce[string](nil, nil); v != nil {
+		t.Errorf("Unexpected value %v", v)
+	}
+	if v := Coalesce[string](); v != nil {
+		t.Errorf("Unexpected value %v", v)
+	}
+	if v := Or(nil, b); v != b {
+		t.Errorf("Unexpected value %v", v)
+	}
+	if v := Or(a, b); v != a {
+		t.Errorf("Unexpected value %v", v)
+	}
+}
+
+func TestMapSlice(t *testing.T) {
+	in := []*int{IntP(1), nil, IntP(3)}
+	out := MapSlice(in, strconv.Itoa)
+	if e, a := []string{"1", "", "3"}, StringSlice(out); !reflect.DeepEqual(e, a) || out[1] != nil {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if e, a := []int{1, 3}, IntSlice(CompactSlice(in)); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if a := CompactSlice([]*int{nil}); a == nil || len(a) != 0 {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
+
+func double(n int) int { return 2 * n }
+
+func BenchmarkMapNil(b *testing.B) {
+	var p *int
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if Map(p, double) != nil {
+			b.Fatal("unexpected value")
+		}
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	p := IntP(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if Map(p, double) == nil {
+			b.Fatal("unexpected nil")
+		}
+	}
+}
+
+func BenchmarkCoalesceNil(b *testing.B) {
+	var p, q *int
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if Coalesce(p, q) != nil {
+			b.Fatal("unexpected value")
+		}
+	}
+}
+
+func BenchmarkFilterNil(b *testing.B) {
+	var p *int
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if Filter(p, func(n int) bool { return n > 0 }) != nil {
+			b.Fatal("unexpected value")
+		}
+	}
+}
+
+func BenchmarkCompactSlice(b *testing.B) {
+	src := make([]*int, 1024)
+	for i := range src {
+		if i%2 == 0 {
+			src[i] = IntP(i)
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CompactSlice(src)
+	}
+}
@@ -0,0 +1,59 @@
+//go:build ignore
+
+// This program generates convert_numeric_gen.go. Invoke it as
+//
+//	go generate
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+var types = []string{
+	"int", "int8", "int16", "int32", "int64",
+	"uint", "uint8", "uint16", "uint32", "uint64",
+	"float32", "float64",
+}
+
+func main() {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_numeric.go; DO NOT EDIT.\n\npackage pointer\n")
+	for _, from := range types {
+		for _, to := range types {
+			if from == to {
+				continue
+			}
+			name := fmt.Sprintf("%sPTo%sP", title(from), title(to))
+			fmt.Fprintf(&b, `
+// %s converts %s pointer into %s pointer, failing if
+// the value does not fit. See ConvertP.
+func %s(p *%s) (*%s, error) {
+	return ConvertP[%s, %s](p)
+}
+`, name, article(from), article(to), name, from, to, from, to)
+		}
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("convert_numeric_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func title(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func article(s string) string {
+	if strings.HasPrefix(s, "int") {
+		return "an " + s
+	}
+	return "a " + s
+}
@@ -0,0 +1,94 @@
+package pointer
+
+import "iter"
+
+// Values returns an iterator over the values of src, with the zero value
+// for nil elements, like StringSlice without allocating a new slice.
+func Values[T any](src []*T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, p := range src {
+			var v T
+			if p != nil {
+				v = *p
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Pointers returns an iterator over pointers to the elements of src, like
+// StringPSlice. The pointers refer to the elements of src itself.
+func Pointers[T any](src []T) iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		for i := range src {
+			if !yield(&src[i]) {
+				return
+			}
+		}
+	}
+}
+
+// NonNil returns an iterator over the values of the non-nil elements of
+// src.
+func NonNil[T any](src []*T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, p := range src {
+			if p != nil && !yield(*p) {
+				return
+			}
+		}
+	}
+}
+
+// MapValues returns an iterator over the keys and values of src, skipping
+// nil values like StringMap. The iteration order is not specified.
+func MapValues[K comparable, V any](src map[K]*V) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, p := range src {
+			if p != nil && !yield(k, *p) {
+				return
+			}
+		}
+	}
+}
+
+// MapPointers returns an iterator over the keys of src and pointers to
+// copies of their values, like StringPMap.
+func MapPointers[K comparable, V any](src map[K]V) iter.Seq2[K, *V] {
+	return func(yield func(K, *V) bool) {
+		for k, v := range src {
+			if !yield(k, &v) {
+				return
+			}
+		}
+	}
+}
+
+// CollectP collects the values of seq into a new []*T, with every
+// pointer referring to a copy of its value. Use slices.Collect to collect
+// them into a []T.
+func CollectP[T any](seq iter.Seq[T]) []*T {
+	var values []T
+	for v := range seq {
+		values = append(values, v)
+	}
+	dst := make([]*T, len(values))
+	for i := range values {
+		dst[i] = &values[i]
+	}
+	return dst
+}
+
+// CollectPMap collects the keys and values of seq into a new map[K]*V,
+// with every pointer referring to a copy of its value. Later values
+// replace earlier ones with the same key. Use maps.Collect to collect
+// them into a map[K]V.
+func CollectPMap[K comparable, V any](seq iter.Seq2[K, V]) map[K]*V {
+	dst := make(map[K]*V)
+	for k, v := range seq {
+		dst[k] = &v
+	}
+	return dst
+}
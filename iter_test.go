@@ -0,0 +1,119 @@
+package pointer
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestValues(t *testing.T) {
+	src := []*string{StringP("a"), nil, StringP("c")}
+	if e, a := StringSlice(src), slices.Collect(Values(src)); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if e, a := []string{"a", "c"}, slices.Collect(NonNil(src)); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected value %v", a)
+	}
+	for v := range Values(src) {
+		if v != "a" {
+			t.Errorf("Unexpected value %s", v)
+		}
+		break
+	}
+	for v := range NonNil(src) {
+		if v != "a" {
+			t.Errorf("Unexpected value %s", v)
+		}
+		break
+	}
+	if a := slices.Collect(Values[int](nil)); len(a) != 0 {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
+
+func TestPointers(t *testing.T) {
+	src := []int64{1, 2, 3}
+	out := slices.Collect(Pointers(src))
+	if e := Int64PSlice(src); !reflect.DeepEqual(e, out) {
+		t.Errorf("Unexpected value %v", out)
+	}
+	*out[0] = 10
+	if src[0] != 10 {
+		t.Errorf("Expected pointers into src, got %v", src)
+	}
+	n := 0
+	for range Pointers(src) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("Unexpected visit count %d", n)
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	src := map[string]*int{"a": IntP(1), "b": nil, "c": IntP(3)}
+	if e, a := IntMap(src), maps.Collect(MapValues(src)); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected value %v", a)
+	}
+	vals := map[string]int{"a": 1, "b": 2}
+	if e, a := IntPMap(vals), maps.Collect(MapPointers(vals)); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected value %v", a)
+	}
+	n := 0
+	for range MapValues(src) {
+		n++
+		break
+	}
+	for range MapPointers(vals) {
+		n++
+		break
+	}
+	if n != 2 {
+		t.Errorf("Unexpected visit count %d", n)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	src := []*float64{Float64P(1), nil, Float64P(3)}
+	out := CollectP(NonNil(src))
+	if e := []*float64{Float64P(1), Float64P(3)}; !reflect.DeepEqual(e, out) {
+		t.Errorf("Unexpected value %v", out)
+	}
+	if out[0] == src[0] {
+		t.Errorf("Expected copies, got aliases")
+	}
+	if out := CollectP(NonNil([]*float64{nil})); len(out) != 0 {
+		t.Errorf("Unexpected value %v", out)
+	}
+
+	m := map[string]*bool{"t": BoolP(true), "n": nil}
+	pm := CollectPMap(MapValues(m))
+	if e := map[string]*bool{"t": BoolP(true)}; !reflect.DeepEqual(e, pm) {
+		t.Errorf("Unexpected value %v", pm)
+	}
+	if pm["t"] == m["t"] {
+		t.Errorf("Expected copies, got aliases")
+	}
+}
+
+func BenchmarkStringSliceRange(b *testing.B) {
+	src := StringPSlice(make([]string, 1<<16))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, v := range StringSlice(src) {
+			_ = v
+		}
+	}
+}
+
+func BenchmarkValuesRange(b *testing.B) {
+	src := StringPSlice(make([]string, 1<<16))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for v := range Values(src) {
+			_ = v
+		}
+	}
+}
@@ -0,0 +1,700 @@
+package pointer
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Operation is a single RFC 6902 JSON Patch operation.
+type Operation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// MarshalJSON encodes o, always including the value member for
+// operations that require one.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type op Operation
+	switch o.Op {
+	case "add", "replace", "test":
+		return json.Marshal(struct {
+			Op    string `json:"op"`
+			Path  string `json:"path"`
+			Value any    `json:"value"`
+		}{o.Op, o.Path, o.Value})
+	}
+	return json.Marshal(op(o))
+}
+
+// PatchError reports a JSON patch operation that could not be applied.
+type PatchError struct {
+	Index int // position of the operation in the patch
+	Op    Operation
+	Err   error
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("pointer: operation %d (%s %s): %v", e.Index, e.Op.Op, e.Op.Path, e.Err)
+}
+
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+// GenerateJSONPatch returns the RFC 6902 operations that transform a into
+// b. Both must be of the same type. Struct fields are addressed by their
+// JSON names; pointers are followed, a nil pointer field being treated as
+// an absent member. Slices and maps are compared element by element.
+func GenerateJSONPatch(a, b any) ([]Operation, error) {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !av.IsValid() || !bv.IsValid() || av.Type() != bv.Type() {
+		return nil, fmt.Errorf("pointer: GenerateJSONPatch requires values of the same type, got %T and %T", a, b)
+	}
+	var ops []Operation
+	if err := diffPatch(&ops, "", av, bv, false); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+func diffPatch(ops *[]Operation, path string, a, b reflect.Value, member bool) error {
+	if isNilable(a.Kind()) && (a.IsNil() || b.IsNil()) {
+		switch {
+		case a.IsNil() && b.IsNil():
+		case member && a.IsNil():
+			*ops = append(*ops, Operation{Op: "add", Path: path, Value: patchValue(b)})
+		case member && b.IsNil():
+			*ops = append(*ops, Operation{Op: "remove", Path: path})
+		default:
+			*ops = append(*ops, Operation{Op: "replace", Path: path, Value: patchValue(b)})
+		}
+		return nil
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		return diffPatch(ops, path, a.Elem(), b.Elem(), false)
+	case reflect.Interface:
+		if a.Elem().Type() == b.Elem().Type() {
+			return diffPatch(ops, path, a.Elem(), b.Elem(), false)
+		}
+	case reflect.Struct:
+		if isJSONLeaf(a.Type()) {
+			break
+		}
+		for _, f := range jsonFields(a.Type()) {
+			af, aerr := fieldByIndex(a, f.index, false)
+			bf, berr := fieldByIndex(b, f.index, false)
+			aok, bok := aerr == nil, berr == nil
+			p := path + "/" + escapePointerToken(f.name)
+			switch {
+			case !aok && !bok:
+			case !aok:
+				*ops = append(*ops, Operation{Op: "add", Path: p, Value: patchValue(bf)})
+			case !bok:
+				*ops = append(*ops, Operation{Op: "remove", Path: p})
+			default:
+				if err := diffPatch(ops, p, af, bf, true); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if isJSONLeaf(a.Type()) {
+			break
+		}
+		n := a.Len()
+		if b.Len() < n {
+			n = b.Len()
+		}
+		for i := 0; i < n; i++ {
+			if err := diffPatch(ops, path+"/"+strconv.Itoa(i), a.Index(i), b.Index(i), false); err != nil {
+				return err
+			}
+		}
+		for i := n; i < b.Len(); i++ {
+			*ops = append(*ops, Operation{Op: "add", Path: path + "/" + strconv.Itoa(i), Value: patchValue(b.Index(i))})
+		}
+		for i := a.Len() - 1; i >= n; i-- {
+			*ops = append(*ops, Operation{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+		}
+		return nil
+	case reflect.Map:
+		if isJSONLeaf(a.Type()) {
+			break
+		}
+		keys := map[string]reflect.Value{}
+		for _, k := range append(a.MapKeys(), b.MapKeys()...) {
+			name, err := patchMapKeyText(k)
+			if err != nil {
+				return fmt.Errorf("pointer: %s: %v", displayPath(path), err)
+			}
+			keys[name] = k
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			k := keys[name]
+			av, bv := a.MapIndex(k), b.MapIndex(k)
+			p := path + "/" + escapePointerToken(name)
+			switch {
+			case !bv.IsValid():
+				*ops = append(*ops, Operation{Op: "remove", Path: p})
+			case !av.IsValid():
+				*ops = append(*ops, Operation{Op: "add", Path: p, Value: patchValue(bv)})
+			default:
+				if err := diffPatch(ops, p, av, bv, false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	equal, err := jsonEqual(a.Interface(), b.Interface())
+	if err != nil {
+		return fmt.Errorf("pointer: %s: %v", displayPath(path), err)
+	}
+	if !equal {
+		*ops = append(*ops, Operation{Op: "replace", Path: path, Value: patchValue(b)})
+	}
+	return nil
+}
+
+// patchValue returns the value carried by an operation for v, with
+// pointers dereferenced.
+func patchValue(v reflect.Value) any {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if isNilable(v.Kind()) && v.IsNil() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// ApplyJSONPatch applies the RFC 6902 operations ops to the struct pointed
+// to by dst. Operations are applied to a copy of the value, and dst is
+// only updated if all of them succeed. A failing operation is reported as
+// a *PatchError.
+func ApplyJSONPatch(dst any, ops []Operation) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("pointer: ApplyJSONPatch requires a non-nil pointer")
+	}
+	doc := deepCopy(rv.Elem())
+	for i, op := range ops {
+		if err := applyOperation(doc, op); err != nil {
+			return &PatchError{Index: i, Op: op, Err: err}
+		}
+	}
+	rv.Elem().Set(doc)
+	return nil
+}
+
+func applyOperation(doc reflect.Value, op Operation) error {
+	tokens, err := parsePointer(op.Path)
+	if err != nil {
+		return err
+	}
+	switch op.Op {
+	case "add":
+		return patchAdd(doc, tokens, op.Value)
+	case "remove":
+		return patchRemove(doc, tokens)
+	case "replace":
+		return patchReplace(doc, tokens, op.Value)
+	case "test":
+		cur, err := patchGet(doc, tokens)
+		if err != nil {
+			return err
+		}
+		equal, err := jsonEqual(patchValue(cur), op.Value)
+		if err != nil {
+			return err
+		}
+		if !equal {
+			return errors.New("test failed: value differs")
+		}
+		return nil
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return err
+		}
+		cur, err := patchGet(doc, from)
+		if err != nil {
+			return fmt.Errorf("from: %v", err)
+		}
+		val := patchValue(deepCopy(cur))
+		if op.Op == "move" {
+			if strings.HasPrefix(op.Path, op.From+"/") {
+				return errors.New("cannot move a value into one of its children")
+			}
+			if err := patchRemove(doc, from); err != nil {
+				return fmt.Errorf("from: %v", err)
+			}
+		}
+		return patchAdd(doc, tokens, val)
+	}
+	return fmt.Errorf("unknown operation %q", op.Op)
+}
+
+func patchAdd(doc reflect.Value, tokens []string, value any) error {
+	if len(tokens) == 0 {
+		return setPatchValue(doc, value)
+	}
+	return walkPatch(doc, tokens, "", func(c reflect.Value, tok, at string) error {
+		switch c.Kind() {
+		case reflect.Struct:
+			f, err := patchField(c, tok, at, true)
+			if err != nil {
+				return err
+			}
+			return setPatchValue(f, value)
+		case reflect.Map:
+			key, err := patchMapKey(c.Type(), tok)
+			if err != nil {
+				return err
+			}
+			ev := reflect.New(c.Type().Elem()).Elem()
+			if err := setPatchValue(ev, value); err != nil {
+				return err
+			}
+			if c.IsNil() {
+				c.Set(reflect.MakeMap(c.Type()))
+			}
+			c.SetMapIndex(key, ev)
+			return nil
+		case reflect.Slice:
+			i := c.Len()
+			if tok != "-" {
+				var err error
+				if i, err = patchIndex(tok, c.Len()+1, at); err != nil {
+					return err
+				}
+			}
+			ev := reflect.New(c.Type().Elem()).Elem()
+			if err := setPatchValue(ev, value); err != nil {
+				return err
+			}
+			s := reflect.MakeSlice(c.Type(), 0, c.Len()+1)
+			s = reflect.AppendSlice(s, c.Slice(0, i))
+			s = reflect.Append(s, ev)
+			s = reflect.AppendSlice(s, c.Slice(i, c.Len()))
+			c.Set(s)
+			return nil
+		}
+		return fmt.Errorf("cannot add %q to %s of kind %s", tok, displayPath(at), c.Kind())
+	})
+}
+
+func patchRemove(doc reflect.Value, tokens []string) error {
+	if len(tokens) == 0 {
+		return errors.New("cannot remove the root value")
+	}
+	return walkPatch(doc, tokens, "", func(c reflect.Value, tok, at string) error {
+		switch c.Kind() {
+		case reflect.Struct:
+			f, err := patchField(c, tok, at, false)
+			if err != nil {
+				return err
+			}
+			f.Set(reflect.Zero(f.Type()))
+			return nil
+		case reflect.Map:
+			key, err := patchMapKey(c.Type(), tok)
+			if err != nil {
+				return err
+			}
+			if !c.MapIndex(key).IsValid() {
+				return fmt.Errorf("no key %q at %s", tok, displayPath(at))
+			}
+			c.SetMapIndex(key, reflect.Value{})
+			return nil
+		case reflect.Slice:
+			i, err := patchIndex(tok, c.Len(), at)
+			if err != nil {
+				return err
+			}
+			s := reflect.MakeSlice(c.Type(), 0, c.Len()-1)
+			s = reflect.AppendSlice(s, c.Slice(0, i))
+			s = reflect.AppendSlice(s, c.Slice(i+1, c.Len()))
+			c.Set(s)
+			return nil
+		}
+		return fmt.Errorf("cannot remove %q from %s of kind %s", tok, displayPath(at), c.Kind())
+	})
+}
+
+func patchReplace(doc reflect.Value, tokens []string, value any) error {
+	if len(tokens) == 0 {
+		return setPatchValue(doc, value)
+	}
+	return walkPatch(doc, tokens, "", func(c reflect.Value, tok, at string) error {
+		switch c.Kind() {
+		case reflect.Struct:
+			f, err := patchField(c, tok, at, false)
+			if err != nil {
+				return err
+			}
+			return setPatchValue(f, value)
+		case reflect.Map:
+			key, err := patchMapKey(c.Type(), tok)
+			if err != nil {
+				return err
+			}
+			if !c.MapIndex(key).IsValid() {
+				return fmt.Errorf("no key %q at %s", tok, displayPath(at))
+			}
+			ev := reflect.New(c.Type().Elem()).Elem()
+			if err := setPatchValue(ev, value); err != nil {
+				return err
+			}
+			c.SetMapIndex(key, ev)
+			return nil
+		case reflect.Slice, reflect.Array:
+			i, err := patchIndex(tok, c.Len(), at)
+			if err != nil {
+				return err
+			}
+			return setPatchValue(c.Index(i), value)
+		}
+		return fmt.Errorf("cannot replace %q in %s of kind %s", tok, displayPath(at), c.Kind())
+	})
+}
+
+func patchGet(doc reflect.Value, tokens []string) (reflect.Value, error) {
+	if len(tokens) == 0 {
+		return doc, nil
+	}
+	var out reflect.Value
+	err := walkPatch(doc, tokens, "", func(c reflect.Value, tok, at string) error {
+		switch c.Kind() {
+		case reflect.Struct:
+			f, err := patchField(c, tok, at, false)
+			out = f
+			return err
+		case reflect.Map:
+			key, err := patchMapKey(c.Type(), tok)
+			if err != nil {
+				return err
+			}
+			if out = c.MapIndex(key); !out.IsValid() {
+				return fmt.Errorf("no key %q at %s", tok, displayPath(at))
+			}
+			return nil
+		case reflect.Slice, reflect.Array:
+			i, err := patchIndex(tok, c.Len(), at)
+			if err != nil {
+				return err
+			}
+			out = c.Index(i)
+			return nil
+		}
+		return fmt.Errorf("cannot read %q from %s of kind %s", tok, displayPath(at), c.Kind())
+	})
+	return out, err
+}
+
+// walkPatch resolves all but the last of tokens under v and calls fn with
+// the container of the target, the last token and the container's path.
+// Map elements and interface values are copied out, updated and stored
+// back, since they are not addressable.
+func walkPatch(v reflect.Value, tokens []string, at string, fn func(c reflect.Value, tok, at string) error) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return fmt.Errorf("%s is nil", displayPath(at))
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return fmt.Errorf("%s is nil", displayPath(at))
+		}
+		e := reflect.New(v.Elem().Type()).Elem()
+		e.Set(v.Elem())
+		if err := walkPatch(e, tokens, at, fn); err != nil {
+			return err
+		}
+		v.Set(e)
+		return nil
+	}
+	if len(tokens) == 1 {
+		return fn(v, tokens[0], at)
+	}
+
+	tok, next := tokens[0], at+"/"+escapePointerToken(tokens[0])
+	switch v.Kind() {
+	case reflect.Struct:
+		f, err := patchField(v, tok, at, true)
+		if err != nil {
+			return err
+		}
+		return walkPatch(f, tokens[1:], next, fn)
+	case reflect.Slice, reflect.Array:
+		i, err := patchIndex(tok, v.Len(), at)
+		if err != nil {
+			return err
+		}
+		return walkPatch(v.Index(i), tokens[1:], next, fn)
+	case reflect.Map:
+		key, err := patchMapKey(v.Type(), tok)
+		if err != nil {
+			return err
+		}
+		cur := v.MapIndex(key)
+		if !cur.IsValid() {
+			return fmt.Errorf("no key %q at %s", tok, displayPath(at))
+		}
+		e := reflect.New(cur.Type()).Elem()
+		e.Set(cur)
+		if err := walkPatch(e, tokens[1:], next, fn); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, e)
+		return nil
+	}
+	return fmt.Errorf("cannot traverse %q in %s of kind %s", tok, displayPath(at), v.Kind())
+}
+
+// patchField returns the field of struct c named tok in JSON. Unless
+// allowNil is set, a nil field is reported as missing.
+func patchField(c reflect.Value, tok, at string, allowNil bool) (reflect.Value, error) {
+	jf, ok := lookupJSONField(c.Type(), tok)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("no field %q at %s", tok, displayPath(at))
+	}
+	f, err := fieldByIndex(c, jf.index, allowNil)
+	if err != nil && !errors.Is(err, errNilEmbedded) {
+		return reflect.Value{}, fmt.Errorf("field %q at %s: %v", tok, displayPath(at), err)
+	}
+	if err != nil || (!allowNil && isNilable(f.Kind()) && f.IsNil()) {
+		return reflect.Value{}, fmt.Errorf("field %q at %s is not set", tok, displayPath(at))
+	}
+	return f, nil
+}
+
+func patchIndex(tok string, n int, at string) (int, error) {
+	i, err := strconv.Atoi(tok)
+	if err != nil || i < 0 || (len(tok) > 1 && tok[0] == '0') {
+		return 0, fmt.Errorf("invalid index %q at %s", tok, displayPath(at))
+	}
+	if i >= n {
+		return 0, fmt.Errorf("index %d out of range at %s", i, displayPath(at))
+	}
+	return i, nil
+}
+
+// patchMapKey parses the path token tok as a key of the map type t. Like
+// encoding/json, it accepts string, integer and encoding.TextUnmarshaler
+// keys.
+func patchMapKey(t reflect.Type, tok string) (reflect.Value, error) {
+	kt := t.Key()
+	k := reflect.New(kt).Elem()
+	switch {
+	case kt.Kind() == reflect.String:
+		k.SetString(tok)
+	case reflect.PtrTo(kt).Implements(textUnmarshalerType):
+		if err := k.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(tok)); err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid map key %q: %v", tok, err)
+		}
+	case kt.Kind() >= reflect.Int && kt.Kind() <= reflect.Int64:
+		n, err := strconv.ParseInt(tok, 10, kt.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid map key %q: %v", tok, err.(*strconv.NumError).Err)
+		}
+		k.SetInt(n)
+	case kt.Kind() >= reflect.Uint && kt.Kind() <= reflect.Uintptr:
+		n, err := strconv.ParseUint(tok, 10, kt.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid map key %q: %v", tok, err.(*strconv.NumError).Err)
+		}
+		k.SetUint(n)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported map key type %s", kt)
+	}
+	return k, nil
+}
+
+// patchMapKeyText formats the map key k as the path token that patchMapKey
+// reads back, as encoding/json names map keys.
+func patchMapKeyText(k reflect.Value) (string, error) {
+	switch {
+	case k.Kind() == reflect.String:
+		return k.String(), nil
+	case k.Type().Implements(textMarshalerType):
+		b, err := k.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	case k.Kind() >= reflect.Int && k.Kind() <= reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case k.Kind() >= reflect.Uint && k.Kind() <= reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported map key type %s", k.Type())
+}
+
+// setPatchValue stores value in v, converting it through its JSON
+// encoding if it is not directly assignable.
+func setPatchValue(v reflect.Value, value any) error {
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Type().AssignableTo(v.Type()):
+		v.Set(deepCopy(rv))
+		return nil
+	case v.Kind() == reflect.Ptr && rv.Type().AssignableTo(v.Type().Elem()):
+		p := reflect.New(v.Type().Elem())
+		p.Elem().Set(deepCopy(rv))
+		v.Set(p)
+		return nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	nv := reflect.New(v.Type())
+	if err := json.Unmarshal(b, nv.Interface()); err != nil {
+		return err
+	}
+	v.Set(nv.Elem())
+	return nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if p[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q", p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens, nil
+}
+
+func escapePointerToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// jsonEqual reports whether a and b have the same JSON encoding, ignoring
+// object key order.
+func jsonEqual(a, b any) (bool, error) {
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(ab, bb) {
+		return true, nil
+	}
+	ag, err := toGenericJSON(json.RawMessage(ab))
+	if err != nil {
+		return false, err
+	}
+	bg, err := toGenericJSON(json.RawMessage(bb))
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(ag, bg), nil
+}
+
+// isJSONLeaf reports whether values of t encode themselves, and should
+// therefore be compared as a whole.
+func isJSONLeaf(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType)
+}
+
+func isNilable(k reflect.Kind) bool {
+	switch k {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+// deepCopy returns an addressable copy of v that shares no pointers,
+// slices or maps with it. Unexported fields are copied shallowly, except
+// for embedded ones: fields promoted through them are settable, so they
+// are copied deeply too.
+func deepCopy(v reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			p := reflect.New(v.Type().Elem())
+			p.Elem().Set(deepCopy(v.Elem()))
+			out.Set(p)
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			out.Set(deepCopy(v.Elem()))
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				s.Index(i).Set(deepCopy(v.Index(i)))
+			}
+			out.Set(s)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(deepCopy(v.Index(i)))
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			m := reflect.MakeMapWithSize(v.Type(), v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				m.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+			}
+			out.Set(m)
+		}
+	case reflect.Struct:
+		if !v.CanAddr() {
+			a := reflect.New(v.Type()).Elem()
+			a.Set(v)
+			v = a
+		}
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			switch {
+			case sf.IsExported():
+				out.Field(i).Set(deepCopy(v.Field(i)))
+			case sf.Anonymous:
+				src := reflect.NewAt(sf.Type, unsafe.Pointer(v.Field(i).UnsafeAddr())).Elem()
+				dst := reflect.NewAt(sf.Type, unsafe.Pointer(out.Field(i).UnsafeAddr())).Elem()
+				dst.Set(deepCopy(src))
+			}
+		}
+	default:
+		out.Set(v)
+	}
+	return out
+}
@@ -0,0 +1,213 @@
+package pointer
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type patchItem struct {
+	ID    *string `json:"id,omitempty"`
+	Count *int64  `json:"count,omitempty"`
+}
+
+type patchDoc struct {
+	Name   *string              `json:"name,omitempty"`
+	Items  []*patchItem         `json:"items,omitempty"`
+	Labels map[string]*string   `json:"labels,omitempty"`
+	Nested map[string]patchItem `json:"nested,omitempty"`
+	Meta   *patchMeta           `json:"meta,omitempty"`
+}
+
+var testCasesJSONPatch = []struct {
+	a, b patchDoc
+	ops  []Operation
+}{
+	{
+		a:   patchDoc{Name: StringP("a")},
+		b:   patchDoc{Name: StringP("b")},
+		ops: []Operation{{Op: "replace", Path: "/name", Value: "b"}},
+	},
+	{
+		a:   patchDoc{Name: StringP("a")},
+		b:   patchDoc{Meta: &patchMeta{Owner: StringP("me")}},
+		ops: []Operation{{Op: "remove", Path: "/name"}, {Op: "add", Path: "/meta", Value: patchMeta{Owner: StringP("me")}}},
+	},
+	{
+		a: patchDoc{Items: []*patchItem{{ID: StringP("x")}, {ID: StringP("y")}, {ID: StringP("z")}}},
+		b: patchDoc{Items: []*patchItem{{ID: StringP("x"), Count: Int64P(2)}}},
+		ops: []Operation{
+			{Op: "add", Path: "/items/0/count", Value: int64(2)},
+			{Op: "remove", Path: "/items/2"},
+			{Op: "remove", Path: "/items/1"},
+		},
+	},
+	{
+		a: patchDoc{Labels: map[string]*string{"a/b": StringP("1"), "c": StringP("2")}},
+		b: patchDoc{Labels: map[string]*string{"a/b": StringP("3"), "d": nil}},
+		ops: []Operation{
+			{Op: "replace", Path: "/labels/a~1b", Value: "3"},
+			{Op: "remove", Path: "/labels/c"},
+			{Op: "add", Path: "/labels/d", Value: nil},
+		},
+	},
+	{
+		a:   patchDoc{Nested: map[string]patchItem{"k": {ID: StringP("x")}}},
+		b:   patchDoc{Nested: map[string]patchItem{"k": {ID: StringP("x"), Count: Int64P(1)}}},
+		ops: []Operation{{Op: "add", Path: "/nested/k/count", Value: int64(1)}},
+	},
+}
+
+func TestGenerateJSONPatch(t *testing.T) {
+	for idx, c := range testCasesJSONPatch {
+		ops, err := GenerateJSONPatch(c.a, c.b)
+		if err != nil {
+			t.Errorf("Unexpected error at idx %d: %v", idx, err)
+			continue
+		}
+		if e, a := c.ops, ops; !reflect.DeepEqual(e, a) {
+			t.Errorf("Unexpected ops at idx %d: %+v", idx, a)
+		}
+	}
+
+	if _, err := GenerateJSONPatch(patchDoc{}, patchItem{}); err == nil {
+		t.Errorf("Expected error for mismatched types")
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	for idx, c := range testCasesJSONPatch {
+		doc := c.a
+		if err := ApplyJSONPatch(&doc, c.ops); err != nil {
+			t.Errorf("Unexpected error at idx %d: %v", idx, err)
+			continue
+		}
+		if e, a := c.b, doc; !reflect.DeepEqual(e, a) {
+			t.Errorf("Unexpected value at idx %d", idx)
+		}
+	}
+}
+
+func TestApplyJSONPatchDecoded(t *testing.T) {
+	var ops []Operation
+	err := json.Unmarshal([]byte(`[
+		{"op":"test","path":"/name","value":"a"},
+		{"op":"add","path":"/items/-","value":{"id":"y","count":3}},
+		{"op":"add","path":"/items/0","value":{"id":"x"}},
+		{"op":"copy","from":"/items/1/count","path":"/items/0/count"},
+		{"op":"move","from":"/name","path":"/meta/owner"}
+	]`), &ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := patchDoc{Name: StringP("a"), Meta: &patchMeta{}}
+	if err := ApplyJSONPatch(&doc, ops); err != nil {
+		t.Fatal(err)
+	}
+	expected := patchDoc{
+		Items: []*patchItem{{ID: StringP("x"), Count: Int64P(3)}, {ID: StringP("y"), Count: Int64P(3)}},
+		Meta:  &patchMeta{Owner: StringP("a")},
+	}
+	if !reflect.DeepEqual(expected, doc) {
+		t.Errorf("Unexpected value %+v", doc)
+	}
+}
+
+func TestApplyJSONPatchErrors(t *testing.T) {
+	cases := []struct {
+		op  Operation
+		msg string
+	}{
+		{Operation{Op: "replace", Path: "/nope", Value: 1}, `pointer: operation 0 (replace /nope): no field "nope" at /`},
+		{Operation{Op: "remove", Path: "/name"}, `pointer: operation 0 (remove /name): field "name" at / is not set`},
+		{Operation{Op: "add", Path: "/items/5", Value: nil}, `pointer: operation 0 (add /items/5): index 5 out of range at /items`},
+		{Operation{Op: "replace", Path: "/meta/owner", Value: "x"}, `pointer: operation 0 (replace /meta/owner): /meta is nil`},
+		{Operation{Op: "test", Path: "/labels/a", Value: "2"}, `pointer: operation 0 (test /labels/a): test failed: value differs`},
+		{Operation{Op: "add", Path: "labels", Value: "2"}, `pointer: operation 0 (add labels): invalid JSON pointer "labels"`},
+		{Operation{Op: "frobnicate", Path: "/name"}, `pointer: operation 0 (frobnicate /name): unknown operation "frobnicate"`},
+	}
+	for idx, c := range cases {
+		doc := patchDoc{Labels: map[string]*string{"a": StringP("1")}}
+		err := ApplyJSONPatch(&doc, []Operation{c.op})
+		var pe *PatchError
+		if !errors.As(err, &pe) {
+			t.Errorf("Expected PatchError at idx %d, got %v", idx, err)
+			continue
+		}
+		if e, a := c.msg, err.Error(); e != a {
+			t.Errorf("Unexpected error at idx %d: %s", idx, a)
+		}
+	}
+
+	doc := patchDoc{Name: StringP("a")}
+	err := ApplyJSONPatch(&doc, []Operation{
+		{Op: "replace", Path: "/name", Value: "b"},
+		{Op: "remove", Path: "/meta"},
+	})
+	if err == nil || String(doc.Name) != "a" {
+		t.Errorf("Expected failed patch to leave value untouched")
+	}
+}
+
+func TestJSONPatchNonStringKeys(t *testing.T) {
+	type doc struct {
+		Ports  map[int]string            `json:"ports"`
+		Limits map[uint8]*int64          `json:"limits"`
+		Zones  map[string]map[int32]bool `json:"zones"`
+	}
+	a := doc{
+		Ports:  map[int]string{80: "http", 443: "https"},
+		Limits: map[uint8]*int64{1: Int64P(10)},
+		Zones:  map[string]map[int32]bool{"a": {-1: false}},
+	}
+	b := doc{
+		Ports:  map[int]string{80: "web", 8080: "alt"},
+		Limits: map[uint8]*int64{1: nil, 2: Int64P(20)},
+		Zones:  map[string]map[int32]bool{"a": {-1: true}, "b": {}},
+	}
+	ops, err := GenerateJSONPatch(a, b)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := ApplyJSONPatch(&a, ops); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Unexpected value %+v", a)
+	}
+
+	for _, path := range []string{"/ports/http", "/limits/256"} {
+		if err := ApplyJSONPatch(&a, []Operation{{Op: "add", Path: path, Value: 1}}); err == nil {
+			t.Errorf("Expected error for malformed key %s", path)
+		}
+	}
+	if _, err := GenerateJSONPatch(map[bool]int{true: 1}, map[bool]int{}); err == nil {
+		t.Errorf("Expected error for bool keys")
+	}
+}
+
+func TestApplyJSONPatchUnexportedEmbedded(t *testing.T) {
+	var v patchOuter
+	if err := ApplyJSONPatch(&v, []Operation{{Op: "add", Path: "/x", Value: 1}}); err == nil {
+		t.Errorf("Expected error for unexported embedded pointer")
+	}
+	if v.patchInner != nil {
+		t.Errorf("Unexpected allocation %+v", v)
+	}
+
+	// A failed patch must not write through the embedded pointer either.
+	inner := &patchInner{X: IntP(1)}
+	v.patchInner = inner
+	ops := []Operation{{Op: "replace", Path: "/x", Value: 5}, {Op: "remove", Path: "/nope"}}
+	var pe *PatchError
+	if err := ApplyJSONPatch(&v, ops); !errors.As(err, &pe) || pe.Index != 1 {
+		t.Errorf("Expected error for operation 1, got %v", err)
+	}
+	if Int(v.X) != 1 || v.patchInner != inner {
+		t.Errorf("Expected failed patch to leave value untouched, got %+v", v.patchInner)
+	}
+	if err := ApplyJSONPatch(&v, ops[:1]); err != nil || Int(v.X) != 5 || Int(inner.X) != 1 {
+		t.Errorf("Unexpected result %+v %v", v.patchInner, err)
+	}
+}
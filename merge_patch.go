@@ -0,0 +1,231 @@
+package pointer
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ApplyMergePatch applies an RFC 7396 JSON merge patch to the struct
+// pointed to by dst. A null in the patch clears the corresponding field
+// (setting pointers to nil), a present value replaces it, allocating
+// pointers as needed, and absent keys leave the field untouched. Nested
+// objects are merged into struct, pointer-to-struct and map fields.
+func ApplyMergePatch(dst any, patch []byte) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("pointer: ApplyMergePatch requires a non-nil pointer")
+	}
+	if !json.Valid(patch) {
+		return errors.New("pointer: invalid merge patch")
+	}
+	return mergeValue(rv.Elem(), patch, "")
+}
+
+func mergeValue(v reflect.Value, raw json.RawMessage, path string) error {
+	if isJSONNull(raw) {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if !isJSONObject(raw) || reflect.PtrTo(v.Type()).Implements(jsonUnmarshalerType) {
+		return replaceValue(v, raw, path)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return mergeValue(v.Elem(), raw, path)
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return fmt.Errorf("pointer: %s: %v", displayPath(path), err)
+		}
+		for name, r := range obj {
+			jf, ok := lookupJSONField(v.Type(), name)
+			if !ok {
+				continue
+			}
+			f, err := fieldByIndex(v, jf.index, true)
+			if err != nil {
+				return fmt.Errorf("pointer: %s: %v", displayPath(path+"/"+name), err)
+			}
+			if err := mergeValue(f, r, path+"/"+name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return replaceValue(v, raw, path)
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return fmt.Errorf("pointer: %s: %v", displayPath(path), err)
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for name, r := range obj {
+			key := reflect.ValueOf(name).Convert(v.Type().Key())
+			if isJSONNull(r) {
+				v.SetMapIndex(key, reflect.Value{})
+				continue
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if cur := v.MapIndex(key); cur.IsValid() {
+				elem.Set(cur)
+			}
+			if err := mergeValue(elem, r, path+"/"+name); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, elem)
+		}
+		return nil
+	case reflect.Interface:
+		var cur any
+		if !v.IsNil() {
+			b, err := json.Marshal(v.Interface())
+			if err != nil {
+				return fmt.Errorf("pointer: %s: %v", displayPath(path), err)
+			}
+			if err := json.Unmarshal(b, &cur); err != nil {
+				return fmt.Errorf("pointer: %s: %v", displayPath(path), err)
+			}
+		}
+		var p any
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return fmt.Errorf("pointer: %s: %v", displayPath(path), err)
+		}
+		merged := mergeGeneric(cur, p)
+		switch {
+		case merged == nil:
+			v.Set(reflect.Zero(v.Type()))
+		case reflect.TypeOf(merged).AssignableTo(v.Type()):
+			v.Set(reflect.ValueOf(merged))
+		default:
+			return fmt.Errorf("pointer: %s: cannot assign %T to %s", displayPath(path), merged, v.Type())
+		}
+		return nil
+	}
+	return replaceValue(v, raw, path)
+}
+
+// replaceValue decodes raw into a fresh value of v's type and stores it in v.
+func replaceValue(v reflect.Value, raw json.RawMessage, path string) error {
+	nv := reflect.New(v.Type())
+	if err := json.Unmarshal(raw, nv.Interface()); err != nil {
+		return fmt.Errorf("pointer: %s: %v", displayPath(path), err)
+	}
+	v.Set(nv.Elem())
+	return nil
+}
+
+// mergeGeneric implements the MergePatch function of RFC 7396 over
+// decoded JSON values.
+func mergeGeneric(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+		} else {
+			t[k] = mergeGeneric(t[k], v)
+		}
+	}
+	return t
+}
+
+// CreateMergePatch returns the RFC 7396 JSON merge patch that transforms
+// the JSON encoding of original into that of modified.
+func CreateMergePatch(original, modified any) ([]byte, error) {
+	o, err := toGenericJSON(original)
+	if err != nil {
+		return nil, err
+	}
+	m, err := toGenericJSON(modified)
+	if err != nil {
+		return nil, err
+	}
+	om, ok1 := o.(map[string]any)
+	mm, ok2 := m.(map[string]any)
+	if !ok1 || !ok2 {
+		return json.Marshal(m)
+	}
+	return json.Marshal(diffGeneric(om, mm))
+}
+
+func diffGeneric(original, modified map[string]any) map[string]any {
+	patch := map[string]any{}
+	for k := range original {
+		if _, ok := modified[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, mv := range modified {
+		ov, ok := original[k]
+		if !ok {
+			patch[k] = mv
+			continue
+		}
+		om, ok1 := ov.(map[string]any)
+		mm, ok2 := mv.(map[string]any)
+		if ok1 && ok2 {
+			if d := diffGeneric(om, mm); len(d) > 0 {
+				patch[k] = d
+			}
+			continue
+		}
+		if !reflect.DeepEqual(ov, mv) {
+			patch[k] = mv
+		}
+	}
+	return patch
+}
+
+func toGenericJSON(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var out any
+	if err := d.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
+
+func isJSONObject(raw json.RawMessage) bool {
+	b := bytes.TrimSpace(raw)
+	return len(b) > 0 && b[0] == '{'
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
@@ -0,0 +1,143 @@
+package pointer
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type patchMeta struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Owner  *string           `json:"owner,omitempty"`
+}
+
+type patchSpec struct {
+	Name     *string    `json:"name,omitempty"`
+	Replicas *int32     `json:"replicas,omitempty"`
+	Paused   *bool      `json:"paused,omitempty"`
+	Meta     *patchMeta `json:"meta,omitempty"`
+	Tags     []string   `json:"tags,omitempty"`
+	Ignored  string     `json:"-"`
+}
+
+var testCasesMergePatch = []struct {
+	in    patchSpec
+	patch string
+	out   patchSpec
+}{
+	{
+		in:    patchSpec{Name: StringP("a"), Replicas: Int32P(1)},
+		patch: `{"replicas":3}`,
+		out:   patchSpec{Name: StringP("a"), Replicas: Int32P(3)},
+	},
+	{
+		in:    patchSpec{Name: StringP("a"), Replicas: Int32P(1)},
+		patch: `{"name":null,"paused":false}`,
+		out:   patchSpec{Replicas: Int32P(1), Paused: BoolP(false)},
+	},
+	{
+		in:    patchSpec{Meta: &patchMeta{Labels: map[string]string{"a": "1", "b": "2"}}},
+		patch: `{"meta":{"labels":{"a":null,"c":"3"},"owner":"me"}}`,
+		out:   patchSpec{Meta: &patchMeta{Labels: map[string]string{"b": "2", "c": "3"}, Owner: StringP("me")}},
+	},
+	{
+		in:    patchSpec{Tags: []string{"x", "y"}},
+		patch: `{"tags":["z"],"meta":{"owner":"me"}}`,
+		out:   patchSpec{Tags: []string{"z"}, Meta: &patchMeta{Owner: StringP("me")}},
+	},
+	{
+		in:    patchSpec{Ignored: "keep"},
+		patch: `{"Ignored":"drop","unknown":1}`,
+		out:   patchSpec{Ignored: "keep"},
+	},
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	for idx, c := range testCasesMergePatch {
+		in := c.in
+		if err := ApplyMergePatch(&in, []byte(c.patch)); err != nil {
+			t.Errorf("Unexpected error at idx %d: %v", idx, err)
+			continue
+		}
+		if e, a := c.out, in; !reflect.DeepEqual(e, a) {
+			t.Errorf("Unexpected value at idx %d", idx)
+		}
+	}
+}
+
+func TestApplyMergePatchErrors(t *testing.T) {
+	var s patchSpec
+	if err := ApplyMergePatch(s, []byte(`{}`)); err == nil {
+		t.Errorf("Expected error for non-pointer destination")
+	}
+	if err := ApplyMergePatch(&s, []byte(`{"replicas":`)); err == nil {
+		t.Errorf("Expected error for invalid patch")
+	}
+	if err := ApplyMergePatch(&s, []byte(`{"replicas":"three"}`)); err == nil {
+		t.Errorf("Expected error for mistyped value")
+	}
+
+	var v struct {
+		S fmt.Stringer `json:"s"`
+	}
+	err := ApplyMergePatch(&v, []byte(`{"s":{"a":1}}`))
+	if e := "pointer: /s: cannot assign map[string]interface {} to fmt.Stringer"; err == nil || err.Error() != e {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	for idx, c := range testCasesMergePatch[:4] {
+		patch, err := CreateMergePatch(c.in, c.out)
+		if err != nil {
+			t.Errorf("Unexpected error at idx %d: %v", idx, err)
+			continue
+		}
+		in := c.in
+		if err := ApplyMergePatch(&in, patch); err != nil {
+			t.Errorf("Unexpected error at idx %d: %v", idx, err)
+			continue
+		}
+		if e, a := c.out, in; !reflect.DeepEqual(e, a) {
+			t.Errorf("Unexpected value at idx %d: patch %s", idx, patch)
+		}
+	}
+
+	patch, err := CreateMergePatch(patchSpec{Name: StringP("a")}, patchSpec{Name: StringP("a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(patch, &m); err != nil || len(m) != 0 {
+		t.Errorf("Expected empty patch, got %s", patch)
+	}
+}
+
+type patchInner struct {
+	X *int `json:"x,omitempty"`
+}
+
+type patchOuter struct {
+	*patchInner
+	Name *string `json:"name,omitempty"`
+}
+
+func TestApplyMergePatchUnexportedEmbedded(t *testing.T) {
+	var v patchOuter
+	if err := ApplyMergePatch(&v, []byte(`{"x":1}`)); err == nil {
+		t.Errorf("Expected error for unexported embedded pointer")
+	}
+	if v.patchInner != nil {
+		t.Errorf("Unexpected allocation %+v", v)
+	}
+
+	// Fields that need no allocation still work.
+	if err := ApplyMergePatch(&v, []byte(`{"name":"a"}`)); err != nil || String(v.Name) != "a" {
+		t.Errorf("Unexpected result %+v %v", v, err)
+	}
+	v.patchInner = &patchInner{}
+	if err := ApplyMergePatch(&v, []byte(`{"x":2}`)); err != nil || Int(v.X) != 2 {
+		t.Errorf("Unexpected result %+v %v", v, err)
+	}
+}
@@ -0,0 +1,181 @@
+package pointer
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// LiftOption configures Lift.
+type LiftOption func(*mirrorConfig)
+
+// OmitZero makes Lift leave pointers nil instead of pointing them to zero
+// values.
+func OmitZero() LiftOption {
+	return func(c *mirrorConfig) {
+		c.zeroAsNil = true
+	}
+}
+
+type mirrorConfig struct {
+	defaults  bool
+	zeroAsNil bool
+}
+
+// Lower copies src, typically a struct whose fields are all pointers, into
+// the same-shaped struct pointed to by dst, whose fields hold values. Nil
+// pointers in src become the value parsed from the `default:"..."` tag of
+// the dst field, if any, or the zero value otherwise.
+//
+// Fields are matched by name, or by the name given in a `mirror:"..."` tag
+// on either side; a `mirror:"-"` tag excludes a field. Unmatched fields
+// are left alone. Nested structs, slices and maps are converted
+// recursively, and fields whose shapes cannot be matched are reported as
+// a *FieldError.
+func Lower(dst, src any) error {
+	return mirror("Lower", dst, src, &mirrorConfig{defaults: true})
+}
+
+// Lift is the inverse of Lower: it copies the value struct src into the
+// same-shaped struct pointed to by dst, allocating a pointer for every
+// field of dst that is a pointer. With OmitZero, zero values in src leave
+// the corresponding pointers nil.
+func Lift(dst, src any, opts ...LiftOption) error {
+	cfg := &mirrorConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return mirror("Lift", dst, src, cfg)
+}
+
+func mirror(fn string, dst, src any, cfg *mirrorConfig) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("pointer: %s requires a non-nil pointer, got %T", fn, dst)
+	}
+	sv := reflect.ValueOf(src)
+	if !sv.IsValid() {
+		return fmt.Errorf("pointer: %s requires a source value, got nil", fn)
+	}
+	return mirrorValue(dv.Elem(), sv, "", cfg)
+}
+
+func mirrorValue(d, s reflect.Value, path string, cfg *mirrorConfig) error {
+	if s.Type() == d.Type() {
+		d.Set(deepCopy(s))
+		return nil
+	}
+	for s.Kind() == reflect.Ptr {
+		if s.IsNil() {
+			d.Set(reflect.Zero(d.Type()))
+			return nil
+		}
+		s = s.Elem()
+	}
+	if d.Kind() == reflect.Ptr {
+		if cfg.zeroAsNil && s.IsZero() {
+			d.Set(reflect.Zero(d.Type()))
+			return nil
+		}
+		p := reflect.New(d.Type().Elem())
+		if err := mirrorValue(p.Elem(), s, path, cfg); err != nil {
+			return err
+		}
+		d.Set(p)
+		return nil
+	}
+
+	switch {
+	case s.Type() == d.Type():
+		d.Set(deepCopy(s))
+	case d.Kind() == reflect.Struct && s.Kind() == reflect.Struct && d.Type() != timeType && s.Type() != timeType:
+		return mirrorStruct(d, s, path, cfg)
+	case d.Kind() == reflect.Slice && s.Kind() == reflect.Slice:
+		if s.IsNil() {
+			d.Set(reflect.Zero(d.Type()))
+			return nil
+		}
+		out := reflect.MakeSlice(d.Type(), s.Len(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			if err := mirrorValue(out.Index(i), s.Index(i), fmt.Sprintf("%s[%d]", path, i), cfg); err != nil {
+				return err
+			}
+		}
+		d.Set(out)
+	case d.Kind() == reflect.Map && s.Kind() == reflect.Map && s.Type().Key().ConvertibleTo(d.Type().Key()) && s.Type().Key().Kind() == d.Type().Key().Kind():
+		if s.IsNil() {
+			d.Set(reflect.Zero(d.Type()))
+			return nil
+		}
+		out := reflect.MakeMapWithSize(d.Type(), s.Len())
+		iter := s.MapRange()
+		for iter.Next() {
+			e := reflect.New(d.Type().Elem()).Elem()
+			if err := mirrorValue(e, iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), cfg); err != nil {
+				return err
+			}
+			out.SetMapIndex(iter.Key().Convert(d.Type().Key()), e)
+		}
+		d.Set(out)
+	case d.Kind() == s.Kind() && isScalarType(d.Type()) && s.Type().ConvertibleTo(d.Type()) && d.Type() != timeType:
+		d.Set(s.Convert(d.Type()))
+	default:
+		return &FieldError{Path: displayField(path), Err: fmt.Errorf("cannot convert %s to %s", s.Type(), d.Type())}
+	}
+	return nil
+}
+
+func mirrorStruct(d, s reflect.Value, path string, cfg *mirrorConfig) error {
+	src := map[string]int{}
+	for i := 0; i < s.NumField(); i++ {
+		if name, ok := mirrorName(s.Type().Field(i)); ok {
+			src[name] = i
+		}
+	}
+	for i := 0; i < d.NumField(); i++ {
+		df := d.Type().Field(i)
+		name, ok := mirrorName(df)
+		if !ok {
+			continue
+		}
+		j, ok := src[name]
+		if !ok {
+			continue
+		}
+		fp := joinPath(path, df.Name)
+		sf := s.Field(j)
+		if def, ok := df.Tag.Lookup("default"); ok && cfg.defaults && isNilValue(sf) {
+			v, err := parseText(df.Type, def)
+			if err != nil {
+				return &FieldError{Path: fp, Err: fmt.Errorf("invalid default: %w", err)}
+			}
+			d.Field(i).Set(v)
+			continue
+		}
+		if err := mirrorValue(d.Field(i), sf, fp, cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mirrorName returns the name sf is matched by in Lower and Lift.
+func mirrorName(sf reflect.StructField) (string, bool) {
+	if !sf.IsExported() {
+		return "", false
+	}
+	switch tag := sf.Tag.Get("mirror"); tag {
+	case "-":
+		return "", false
+	case "":
+		return sf.Name, true
+	default:
+		return tag, true
+	}
+}
+
+func displayField(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
@@ -0,0 +1,158 @@
+package pointer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type wireItem struct {
+	ID    *string
+	Count *int64
+}
+
+type wireConfig struct {
+	Name     *string
+	Replicas *int32
+	Timeout  *time.Duration
+	Started  *time.Time
+	Items    []*wireItem
+	ByName   map[string]*wireItem
+	Labels   *map[string]string
+	Primary  *wireItem
+	Alias    *string `mirror:"Nick"`
+	Secret   *string `mirror:"-"`
+}
+
+type internalItem struct {
+	ID    string
+	Count int64
+}
+
+type internalConfig struct {
+	Name     string
+	Replicas int32         `default:"1"`
+	Timeout  time.Duration `default:"30s"`
+	Started  time.Time
+	Items    []internalItem
+	ByName   map[string]internalItem
+	Labels   map[string]string
+	Primary  internalItem
+	Nick     string
+	Secret   string
+	Extra    string
+}
+
+func TestLower(t *testing.T) {
+	now := time.Now()
+	src := wireConfig{
+		Name:    StringP("a"),
+		Started: &now,
+		Items:   []*wireItem{{ID: StringP("x"), Count: Int64P(2)}, nil},
+		ByName:  map[string]*wireItem{"k": {ID: StringP("y")}},
+		Labels:  &map[string]string{"a": "1"},
+		Alias:   StringP("nick"),
+		Secret:  StringP("s"),
+	}
+	dst := internalConfig{Extra: "keep"}
+	if err := Lower(&dst, &src); err != nil {
+		t.Fatal(err)
+	}
+	expected := internalConfig{
+		Name:     "a",
+		Replicas: 1,
+		Timeout:  30 * time.Second,
+		Started:  now,
+		Items:    []internalItem{{ID: "x", Count: 2}, {}},
+		ByName:   map[string]internalItem{"k": {ID: "y"}},
+		Labels:   map[string]string{"a": "1"},
+		Nick:     "nick",
+		Extra:    "keep",
+	}
+	if !reflect.DeepEqual(expected, dst) {
+		t.Errorf("Unexpected value %+v", dst)
+	}
+	(*src.Labels)["a"] = "2"
+	if dst.Labels["a"] != "1" {
+		t.Errorf("Expected maps to be copied")
+	}
+}
+
+func TestLift(t *testing.T) {
+	src := internalConfig{
+		Name:   "a",
+		Items:  []internalItem{{ID: "x"}},
+		ByName: map[string]internalItem{"k": {Count: 3}},
+		Nick:   "nick",
+		Secret: "s",
+	}
+	var dst wireConfig
+	if err := Lift(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	expected := wireConfig{
+		Name:     StringP("a"),
+		Replicas: Int32P(0),
+		Timeout:  DurationP(0),
+		Started:  &time.Time{},
+		Items:    []*wireItem{{ID: StringP("x"), Count: Int64P(0)}},
+		ByName:   map[string]*wireItem{"k": {ID: StringP(""), Count: Int64P(3)}},
+		Labels:   new(map[string]string),
+		Primary:  &wireItem{ID: StringP(""), Count: Int64P(0)},
+		Alias:    StringP("nick"),
+	}
+	if !reflect.DeepEqual(expected, dst) {
+		t.Errorf("Unexpected value %+v", dst)
+	}
+
+	var sparse wireConfig
+	if err := Lift(&sparse, src, OmitZero()); err != nil {
+		t.Fatal(err)
+	}
+	expected = wireConfig{
+		Name:   StringP("a"),
+		Items:  []*wireItem{{ID: StringP("x")}},
+		ByName: map[string]*wireItem{"k": {Count: Int64P(3)}},
+		Alias:  StringP("nick"),
+	}
+	if !reflect.DeepEqual(expected, sparse) {
+		t.Errorf("Unexpected value %+v", sparse)
+	}
+
+	var back internalConfig
+	if err := Lower(&back, &sparse); err != nil {
+		t.Fatal(err)
+	}
+	src.Replicas, src.Timeout, src.Secret = 1, 30*time.Second, ""
+	if !reflect.DeepEqual(src, back) {
+		t.Errorf("Unexpected round trip %+v", back)
+	}
+}
+
+func TestMirrorErrors(t *testing.T) {
+	var dst struct {
+		Items []struct{ Count int32 }
+	}
+	src := struct {
+		Items []*wireItem
+	}{Items: []*wireItem{{Count: Int64P(1)}}}
+	err := Lower(&dst, src)
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Path != "Items[0].Count" {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	var bad struct {
+		Name int `default:"x"`
+	}
+	if err := Lower(&bad, struct{ Name *int }{}); err == nil {
+		t.Errorf("Expected error for invalid default")
+	}
+	if err := Lower(dst, src); err == nil {
+		t.Errorf("Expected error for non-pointer destination")
+	}
+	if err := Lift(&dst, nil); err == nil {
+		t.Errorf("Expected error for nil source")
+	}
+}
@@ -0,0 +1,89 @@
+package pointer
+
+import "reflect"
+
+// Ptr2 returns a pointer to a pointer to the value passed in.
+func Ptr2[T any](v T) **T {
+	p := &v
+	return &p
+}
+
+// Deref2 returns the value a double pointer leads to, or the zero value
+// of T if either pointer is nil.
+func Deref2[T any](pp **T) T {
+	if pp != nil && *pp != nil {
+		return **pp
+	}
+	var zero T
+	return zero
+}
+
+// DerefN follows any number of pointers in v until it reaches a T. ok is
+// false if a nil pointer is found first or v does not lead to a T. It uses
+// reflection, for values whose pointer depth is not known at compile time.
+func DerefN[T any](v any) (T, bool) {
+	var zero T
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() {
+		if rv.Type() == t {
+			return rv.Interface().(T), true
+		}
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			break
+		}
+		rv = rv.Elem()
+	}
+	return zero, false
+}
+
+// PtrN wraps v in n levels of pointers, each pointing to a new copy, so
+// PtrN(3, 2) returns an **int. PtrN(v, 0) returns v, and a nil v has no
+// type to point to, so the result is nil.
+func PtrN(v any, n int) any {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		rv = PtrValue(rv)
+	}
+	return rv.Interface()
+}
+
+// PtrDepth returns the number of pointer levels in the type of v, e.g. 2
+// for a **string. It returns 0 for nil.
+func PtrDepth(v any) int {
+	n := 0
+	for t := reflect.TypeOf(v); t != nil && t.Kind() == reflect.Ptr; t = t.Elem() {
+		n++
+	}
+	return n
+}
+
+// DerefSliceP converts a pointer to a slice of pointers into a slice of
+// values. As with StringSlice, nil elements become zero values; a nil p
+// yields an empty slice.
+func DerefSliceP[T any](p *[]*T) []T {
+	if p == nil {
+		return make([]T, 0)
+	}
+	src := *p
+	dst := make([]T, len(src))
+	for i := 0; i < len(src); i++ {
+		if src[i] != nil {
+			dst[i] = *(src[i])
+		}
+	}
+	return dst
+}
+
+// PtrSliceP converts a slice of values into a pointer to a slice of
+// pointers to its elements, the inverse of DerefSliceP.
+func PtrSliceP[T any](src []T) *[]*T {
+	dst := make([]*T, len(src))
+	for i := 0; i < len(src); i++ {
+		dst[i] = &(src[i])
+	}
+	return &dst
+}
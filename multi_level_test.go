@@ -0,0 +1,93 @@
+package pointer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPtr2(t *testing.T) {
+	pp := Ptr2("a")
+	if e, a := "a", Deref2(pp); e != a {
+		t.Errorf("Unexpected value %v", a)
+	}
+	*pp = nil
+	if a := Deref2(pp); a != "" {
+		t.Errorf("Unexpected value %v", a)
+	}
+	if a := Deref2[int](nil); a != 0 {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
+
+func TestDerefN(t *testing.T) {
+	s := StringP("a")
+	cases := []struct {
+		in any
+		v  string
+		ok bool
+	}{
+		{"a", "a", true},
+		{s, "a", true},
+		{&s, "a", true},
+		{PtrN("a", 4), "a", true},
+		{(**string)(nil), "", false},
+		{Ptr2[*string](nil), "", false},
+		{IntP(1), "", false},
+		{nil, "", false},
+	}
+	for idx, c := range cases {
+		v, ok := DerefN[string](c.in)
+		if v != c.v || ok != c.ok {
+			t.Errorf("Unexpected value %q %v at idx %d", v, ok, idx)
+		}
+	}
+	if v, ok := DerefN[*string](&s); !ok || v != s {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+}
+
+func TestPtrN(t *testing.T) {
+	if e, a := 4, PtrDepth(PtrN(3, 4)); e != a {
+		t.Errorf("Unexpected depth %d", a)
+	}
+	if v := PtrN(3, 0); v != 3 {
+		t.Errorf("Unexpected value %v", v)
+	}
+	if v := PtrN(nil, 2); v != nil {
+		t.Errorf("Unexpected value %v", v)
+	}
+	if d := PtrDepth(nil); d != 0 {
+		t.Errorf("Unexpected depth %d", d)
+	}
+}
+
+var testCasesDerefSliceP = []*[]*string{
+	{StringP("a"), nil, StringP("c")},
+	{},
+	nil,
+}
+
+func TestDerefSliceP(t *testing.T) {
+	for idx, in := range testCasesDerefSliceP {
+		out := DerefSliceP(in)
+		var expected []string
+		if in == nil {
+			expected = StringSlice(nil)
+		} else {
+			expected = StringSlice(*in)
+		}
+		if !reflect.DeepEqual(expected, out) {
+			t.Errorf("Unexpected value at idx %d", idx)
+		}
+
+		out2 := PtrSliceP(out)
+		if e, a := len(out), len(*out2); e != a {
+			t.Errorf("Unexpected len at idx %d", idx)
+		}
+		for i := range out {
+			if e, a := out[i], *(*out2)[i]; e != a {
+				t.Errorf("Unexpected value at idx %d", idx)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package pointer
+
+import (
+	"runtime"
+	"sync"
+)
+
+// defaultParallelThreshold is the default minimum chunk size of the
+// parallel conversions. Below about this many elements per goroutine,
+// starting goroutines costs more than it saves; see the benchmarks.
+const defaultParallelThreshold = 1 << 15
+
+// ParallelOption configures the parallel conversions.
+type ParallelOption func(*parallelConfig)
+
+type parallelConfig struct {
+	workers   int
+	threshold int
+}
+
+// WithWorkers sets the maximum number of goroutines a conversion uses.
+// The default is runtime.GOMAXPROCS(0).
+func WithWorkers(n int) ParallelOption {
+	return func(c *parallelConfig) {
+		c.workers = n
+	}
+}
+
+// WithThreshold sets the minimum number of elements each goroutine
+// converts, so that inputs smaller than twice the threshold are
+// converted sequentially. The default is 32768.
+func WithThreshold(n int) ParallelOption {
+	return func(c *parallelConfig) {
+		c.threshold = n
+	}
+}
+
+// parallelChunks splits [0, n) into chunks of at least the threshold, at
+// most one per worker, and calls fn for each chunk in its own goroutine.
+// It returns once all calls have returned.
+func parallelChunks(n int, opts []ParallelOption, fn func(lo, hi int)) {
+	cfg := parallelConfig{workers: runtime.GOMAXPROCS(0), threshold: defaultParallelThreshold}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	chunks := min(cfg.workers, n/max(cfg.threshold, 1))
+	if chunks <= 1 {
+		fn(0, n)
+		return
+	}
+	size := (n + chunks - 1) / chunks
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += size {
+		hi := min(lo+size, n)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(lo, hi)
+		}()
+	}
+	wg.Wait()
+}
+
+// ParallelSlice converts a slice of pointers into a slice of values like
+// the typed Slice functions, such as Int64Slice, splitting the work
+// across goroutines. Nil elements become zero values.
+func ParallelSlice[T any](src []*T, opts ...ParallelOption) []T {
+	dst := make([]T, len(src))
+	parallelChunks(len(src), opts, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			if src[i] != nil {
+				dst[i] = *src[i]
+			}
+		}
+	})
+	return dst
+}
+
+// ParallelPSlice converts a slice of values into a slice of pointers like
+// the typed PSlice functions, such as Float64PSlice, splitting the work
+// across goroutines. The pointers refer to the elements of src itself.
+func ParallelPSlice[T any](src []T, opts ...ParallelOption) []*T {
+	dst := make([]*T, len(src))
+	parallelChunks(len(src), opts, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			dst[i] = &src[i]
+		}
+	})
+	return dst
+}
+
+// ParallelMap converts a map of pointers into a map of values like the
+// typed Map functions, such as Int64Map, skipping nil values. The keys
+// are split into disjoint shards that are converted concurrently and
+// then merged.
+func ParallelMap[K comparable, V any](src map[K]*V, opts ...ParallelOption) map[K]V {
+	return parallelMap(src, opts, func(p *V) (V, bool) {
+		if p == nil {
+			var zero V
+			return zero, false
+		}
+		return *p, true
+	})
+}
+
+// ParallelPMap converts a map of values into a map of pointers like the
+// typed PMap functions, such as Int64PMap, with every pointer referring
+// to a copy of its value. The keys are split into disjoint shards that
+// are converted concurrently and then merged.
+func ParallelPMap[K comparable, V any](src map[K]V, opts ...ParallelOption) map[K]*V {
+	return parallelMap(src, opts, func(v V) (*V, bool) {
+		return &v, true
+	})
+}
+
+func parallelMap[K comparable, V, W any](src map[K]V, opts []ParallelOption, conv func(V) (W, bool)) map[K]W {
+	keys := make([]K, 0, len(src))
+	for k := range src {
+		keys = append(keys, k)
+	}
+	var mu sync.Mutex
+	var shards []map[K]W
+	parallelChunks(len(keys), opts, func(lo, hi int) {
+		shard := make(map[K]W, hi-lo)
+		for _, k := range keys[lo:hi] {
+			if w, ok := conv(src[k]); ok {
+				shard[k] = w
+			}
+		}
+		mu.Lock()
+		shards = append(shards, shard)
+		mu.Unlock()
+	})
+	if len(shards) == 1 {
+		return shards[0]
+	}
+	dst := make(map[K]W, len(src))
+	for _, shard := range shards {
+		for k, w := range shard {
+			dst[k] = w
+		}
+	}
+	return dst
+}
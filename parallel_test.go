@@ -0,0 +1,148 @@
+package pointer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParallelSlice(t *testing.T) {
+	src := make([]*int64, 1001)
+	for i := range src {
+		if i%7 != 0 {
+			src[i] = Int64P(int64(i))
+		}
+	}
+	cases := [][]ParallelOption{
+		nil,
+		{WithWorkers(4), WithThreshold(1)},
+		{WithWorkers(3), WithThreshold(100)},
+		{WithWorkers(0), WithThreshold(0)},
+		{WithWorkers(2000), WithThreshold(-1)},
+	}
+	for idx, opts := range cases {
+		if e, a := Int64Slice(src), ParallelSlice(src, opts...); !reflect.DeepEqual(e, a) {
+			t.Errorf("Unexpected value at idx %d", idx)
+		}
+		vals := Float64Slice(Float64PSlice(make([]float64, 999)))
+		out := ParallelPSlice(vals, opts...)
+		if e := Float64PSlice(vals); !reflect.DeepEqual(e, out) {
+			t.Errorf("Unexpected value at idx %d", idx)
+		}
+		for i := range out {
+			if out[i] != &vals[i] {
+				t.Fatalf("Expected pointers into src at idx %d", idx)
+			}
+		}
+	}
+	if a := ParallelSlice[int64](nil); a == nil || len(a) != 0 {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
+
+func TestParallelMap(t *testing.T) {
+	src := map[string]*int64{}
+	vals := map[string]int64{}
+	for i := 0; i < 1000; i++ {
+		k := strconv.Itoa(i)
+		vals[k] = int64(i)
+		if i%5 != 0 {
+			src[k] = Int64P(int64(i))
+		} else {
+			src[k] = nil
+		}
+	}
+	cases := [][]ParallelOption{
+		nil,
+		{WithWorkers(4), WithThreshold(1)},
+		{WithWorkers(3), WithThreshold(300)},
+	}
+	for idx, opts := range cases {
+		if e, a := Int64Map(src), ParallelMap(src, opts...); !reflect.DeepEqual(e, a) {
+			t.Errorf("Unexpected value at idx %d", idx)
+		}
+		out := ParallelPMap(vals, opts...)
+		if e := Int64PMap(vals); !reflect.DeepEqual(e, out) {
+			t.Errorf("Unexpected value at idx %d", idx)
+		}
+		*out["1"] = -1
+		if vals["1"] != 1 {
+			t.Errorf("Expected copies at idx %d", idx)
+		}
+	}
+	if a := ParallelMap[string, int](nil); a == nil || len(a) != 0 {
+		t.Errorf("Unexpected value %v", a)
+	}
+}
+
+func TestParallelUneven(t *testing.T) {
+	for n := 0; n <= 20; n++ {
+		vals := make(map[int]int, n)
+		for i := 0; i < n; i++ {
+			vals[i] = i
+		}
+		src := IntPSlice(make([]int, n))
+		for workers := 1; workers <= 8; workers++ {
+			opts := []ParallelOption{WithWorkers(workers), WithThreshold(1)}
+			if e, a := IntSlice(src), ParallelSlice(src, opts...); !reflect.DeepEqual(e, a) {
+				t.Errorf("Unexpected slice for n=%d workers=%d", n, workers)
+			}
+			if out := ParallelPMap(vals, opts...); len(out) != n {
+				t.Errorf("Unexpected map for n=%d workers=%d: %v", n, workers, out)
+			}
+		}
+	}
+}
+
+var parallelBenchSizes = []int{1 << 10, 1 << 14, 1 << 17, 1 << 20, 1 << 23}
+
+func BenchmarkInt64Slice(b *testing.B) {
+	for _, n := range parallelBenchSizes {
+		src := Int64PSlice(make([]int64, n))
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Int64Slice(src)
+			}
+		})
+	}
+}
+
+func BenchmarkParallelSlice(b *testing.B) {
+	for _, n := range parallelBenchSizes {
+		src := Int64PSlice(make([]int64, n))
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ParallelSlice(src, WithThreshold(1<<10))
+			}
+		})
+	}
+}
+
+func BenchmarkInt64Map(b *testing.B) {
+	for _, n := range parallelBenchSizes[:4] {
+		src := make(map[string]*int64, n)
+		for i := 0; i < n; i++ {
+			src[strconv.Itoa(i)] = Int64P(int64(i))
+		}
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Int64Map(src)
+			}
+		})
+	}
+}
+
+func BenchmarkParallelMap(b *testing.B) {
+	for _, n := range parallelBenchSizes[:4] {
+		src := make(map[string]*int64, n)
+		for i := 0; i < n; i++ {
+			src[strconv.Itoa(i)] = Int64P(int64(i))
+		}
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ParallelMap(src, WithThreshold(1<<10))
+			}
+		})
+	}
+}
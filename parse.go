@@ -0,0 +1,419 @@
+package pointer
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// parseP returns nil for an empty s and a pointer to the value parsed by
+// parse otherwise.
+func parseP[T any](s string, parse func(string) (T, error)) (*T, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// parsePSlice applies parseP to every element of src, failing with the
+// first error encountered.
+func parsePSlice[T any](src []string, parse func(string) (T, error)) ([]*T, error) {
+	dst := make([]*T, len(src))
+	for i := 0; i < len(src); i++ {
+		v, err := parseP(src[i], parse)
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %w", i, err)
+		}
+		dst[i] = v
+	}
+	return dst, nil
+}
+
+// ParseStringP returns nil for an empty string and a pointer to s
+// otherwise.
+func ParseStringP(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// FormatStringP returns the value of the string pointer passed in, or
+// nilText if the pointer is nil.
+func FormatStringP(p *string, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return *p
+}
+
+// ParseStringPSlice applies ParseStringP to every element of src.
+func ParseStringPSlice(src []string) []*string {
+	dst := make([]*string, len(src))
+	for i := 0; i < len(src); i++ {
+		dst[i] = ParseStringP(src[i])
+	}
+	return dst
+}
+
+// ParseBoolP returns nil for an empty string and a pointer to the
+// bool value parsed by strconv.ParseBool otherwise.
+func ParseBoolP(s string) (*bool, error) {
+	return parseP(s, strconv.ParseBool)
+}
+
+// FormatBoolP returns the bool value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatBoolP(p *bool, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatBool(*p)
+}
+
+// ParseBoolPSlice applies ParseBoolP to every element of src.
+func ParseBoolPSlice(src []string) ([]*bool, error) {
+	return parsePSlice(src, strconv.ParseBool)
+}
+
+// ParseIntP returns nil for an empty string and a pointer to the
+// int value parsed by strconv.Atoi otherwise.
+func ParseIntP(s string) (*int, error) {
+	return parseP(s, strconv.Atoi)
+}
+
+// FormatIntP returns the int value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatIntP(p *int, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.Itoa(*p)
+}
+
+// ParseIntPSlice applies ParseIntP to every element of src.
+func ParseIntPSlice(src []string) ([]*int, error) {
+	return parsePSlice(src, strconv.Atoi)
+}
+
+func parseInt8(s string) (int8, error) {
+	n, err := strconv.ParseInt(s, 10, 8)
+	return int8(n), err
+}
+
+// ParseInt8P returns nil for an empty string and a pointer to the
+// int8 value parsed by strconv.ParseInt otherwise.
+func ParseInt8P(s string) (*int8, error) {
+	return parseP(s, parseInt8)
+}
+
+// FormatInt8P returns the int8 value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatInt8P(p *int8, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatInt(int64(*p), 10)
+}
+
+// ParseInt8PSlice applies ParseInt8P to every element of src.
+func ParseInt8PSlice(src []string) ([]*int8, error) {
+	return parsePSlice(src, parseInt8)
+}
+
+func parseInt16(s string) (int16, error) {
+	n, err := strconv.ParseInt(s, 10, 16)
+	return int16(n), err
+}
+
+// ParseInt16P returns nil for an empty string and a pointer to the
+// int16 value parsed by strconv.ParseInt otherwise.
+func ParseInt16P(s string) (*int16, error) {
+	return parseP(s, parseInt16)
+}
+
+// FormatInt16P returns the int16 value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatInt16P(p *int16, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatInt(int64(*p), 10)
+}
+
+// ParseInt16PSlice applies ParseInt16P to every element of src.
+func ParseInt16PSlice(src []string) ([]*int16, error) {
+	return parsePSlice(src, parseInt16)
+}
+
+func parseInt32(s string) (int32, error) {
+	n, err := strconv.ParseInt(s, 10, 32)
+	return int32(n), err
+}
+
+// ParseInt32P returns nil for an empty string and a pointer to the
+// int32 value parsed by strconv.ParseInt otherwise.
+func ParseInt32P(s string) (*int32, error) {
+	return parseP(s, parseInt32)
+}
+
+// FormatInt32P returns the int32 value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatInt32P(p *int32, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatInt(int64(*p), 10)
+}
+
+// ParseInt32PSlice applies ParseInt32P to every element of src.
+func ParseInt32PSlice(src []string) ([]*int32, error) {
+	return parsePSlice(src, parseInt32)
+}
+
+func parseInt64(s string) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	return int64(n), err
+}
+
+// ParseInt64P returns nil for an empty string and a pointer to the
+// int64 value parsed by strconv.ParseInt otherwise.
+func ParseInt64P(s string) (*int64, error) {
+	return parseP(s, parseInt64)
+}
+
+// FormatInt64P returns the int64 value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatInt64P(p *int64, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatInt(int64(*p), 10)
+}
+
+// ParseInt64PSlice applies ParseInt64P to every element of src.
+func ParseInt64PSlice(src []string) ([]*int64, error) {
+	return parsePSlice(src, parseInt64)
+}
+
+func parseUint(s string) (uint, error) {
+	n, err := strconv.ParseUint(s, 10, 0)
+	return uint(n), err
+}
+
+// ParseUintP returns nil for an empty string and a pointer to the
+// uint value parsed by strconv.ParseUint otherwise.
+func ParseUintP(s string) (*uint, error) {
+	return parseP(s, parseUint)
+}
+
+// FormatUintP returns the uint value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatUintP(p *uint, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatUint(uint64(*p), 10)
+}
+
+// ParseUintPSlice applies ParseUintP to every element of src.
+func ParseUintPSlice(src []string) ([]*uint, error) {
+	return parsePSlice(src, parseUint)
+}
+
+func parseUint8(s string) (uint8, error) {
+	n, err := strconv.ParseUint(s, 10, 8)
+	return uint8(n), err
+}
+
+// ParseUint8P returns nil for an empty string and a pointer to the
+// uint8 value parsed by strconv.ParseUint otherwise.
+func ParseUint8P(s string) (*uint8, error) {
+	return parseP(s, parseUint8)
+}
+
+// FormatUint8P returns the uint8 value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatUint8P(p *uint8, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatUint(uint64(*p), 10)
+}
+
+// ParseUint8PSlice applies ParseUint8P to every element of src.
+func ParseUint8PSlice(src []string) ([]*uint8, error) {
+	return parsePSlice(src, parseUint8)
+}
+
+func parseUint16(s string) (uint16, error) {
+	n, err := strconv.ParseUint(s, 10, 16)
+	return uint16(n), err
+}
+
+// ParseUint16P returns nil for an empty string and a pointer to the
+// uint16 value parsed by strconv.ParseUint otherwise.
+func ParseUint16P(s string) (*uint16, error) {
+	return parseP(s, parseUint16)
+}
+
+// FormatUint16P returns the uint16 value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatUint16P(p *uint16, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatUint(uint64(*p), 10)
+}
+
+// ParseUint16PSlice applies ParseUint16P to every element of src.
+func ParseUint16PSlice(src []string) ([]*uint16, error) {
+	return parsePSlice(src, parseUint16)
+}
+
+func parseUint32(s string) (uint32, error) {
+	n, err := strconv.ParseUint(s, 10, 32)
+	return uint32(n), err
+}
+
+// ParseUint32P returns nil for an empty string and a pointer to the
+// uint32 value parsed by strconv.ParseUint otherwise.
+func ParseUint32P(s string) (*uint32, error) {
+	return parseP(s, parseUint32)
+}
+
+// FormatUint32P returns the uint32 value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatUint32P(p *uint32, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatUint(uint64(*p), 10)
+}
+
+// ParseUint32PSlice applies ParseUint32P to every element of src.
+func ParseUint32PSlice(src []string) ([]*uint32, error) {
+	return parsePSlice(src, parseUint32)
+}
+
+func parseUint64(s string) (uint64, error) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	return uint64(n), err
+}
+
+// ParseUint64P returns nil for an empty string and a pointer to the
+// uint64 value parsed by strconv.ParseUint otherwise.
+func ParseUint64P(s string) (*uint64, error) {
+	return parseP(s, parseUint64)
+}
+
+// FormatUint64P returns the uint64 value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatUint64P(p *uint64, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatUint(uint64(*p), 10)
+}
+
+// ParseUint64PSlice applies ParseUint64P to every element of src.
+func ParseUint64PSlice(src []string) ([]*uint64, error) {
+	return parsePSlice(src, parseUint64)
+}
+
+func parseFloat32(s string) (float32, error) {
+	f, err := strconv.ParseFloat(s, 32)
+	return float32(f), err
+}
+
+// ParseFloat32P returns nil for an empty string and a pointer to the
+// float32 value parsed by strconv.ParseFloat otherwise.
+func ParseFloat32P(s string) (*float32, error) {
+	return parseP(s, parseFloat32)
+}
+
+// FormatFloat32P returns the float32 value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatFloat32P(p *float32, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatFloat(float64(*p), 'g', -1, 32)
+}
+
+// ParseFloat32PSlice applies ParseFloat32P to every element of src.
+func ParseFloat32PSlice(src []string) ([]*float32, error) {
+	return parsePSlice(src, parseFloat32)
+}
+
+func parseFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+// ParseFloat64P returns nil for an empty string and a pointer to the
+// float64 value parsed by strconv.ParseFloat otherwise.
+func ParseFloat64P(s string) (*float64, error) {
+	return parseP(s, parseFloat64)
+}
+
+// FormatFloat64P returns the float64 value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatFloat64P(p *float64, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return strconv.FormatFloat(*p, 'g', -1, 64)
+}
+
+// ParseFloat64PSlice applies ParseFloat64P to every element of src.
+func ParseFloat64PSlice(src []string) ([]*float64, error) {
+	return parsePSlice(src, parseFloat64)
+}
+
+// ParseDurationP returns nil for an empty string and a pointer to the
+// time.Duration value parsed by time.ParseDuration otherwise.
+func ParseDurationP(s string) (*time.Duration, error) {
+	return parseP(s, time.ParseDuration)
+}
+
+// FormatDurationP returns the time.Duration value of the pointer passed in as a
+// string, or nilText if the pointer is nil.
+func FormatDurationP(p *time.Duration, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return p.String()
+}
+
+// ParseDurationPSlice applies ParseDurationP to every element of src.
+func ParseDurationPSlice(src []string) ([]*time.Duration, error) {
+	return parsePSlice(src, time.ParseDuration)
+}
+
+// ParseTimeP returns nil for an empty string and a pointer to the
+// time.Time value parsed by time.Parse with layout otherwise.
+func ParseTimeP(layout, s string) (*time.Time, error) {
+	return parseP(s, func(s string) (time.Time, error) {
+		return time.Parse(layout, s)
+	})
+}
+
+// FormatTimeP returns the time.Time value of the pointer passed in
+// formatted with layout, or nilText if the pointer is nil.
+func FormatTimeP(p *time.Time, layout, nilText string) string {
+	if p == nil {
+		return nilText
+	}
+	return p.Format(layout)
+}
+
+// ParseTimePSlice applies ParseTimeP with layout to every element of src.
+func ParseTimePSlice(layout string, src []string) ([]*time.Time, error) {
+	return parsePSlice(src, func(s string) (time.Time, error) {
+		return time.Parse(layout, s)
+	})
+}
@@ -0,0 +1,96 @@
+package pointer
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseP(t *testing.T) {
+	if v, err := ParseIntP("42"); err != nil || *v != 42 {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v, err := ParseInt32P(""); err != nil || v != nil {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v, err := ParseUint64P("18446744073709551615"); err != nil || *v != 1<<64-1 {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v, err := ParseFloat64P("2.5"); err != nil || *v != 2.5 {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v, err := ParseFloat32P("0.1"); err != nil || *v != float32(0.1) {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v, err := ParseBoolP("true"); err != nil || !*v {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v, err := ParseDurationP("1m30s"); err != nil || *v != 90*time.Second {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v, err := ParseTimeP(time.DateOnly, "2020-01-02"); err != nil || !v.Equal(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected value %v %v", v, err)
+	}
+	if v := ParseStringP(""); v != nil {
+		t.Errorf("Unexpected value %v", v)
+	}
+	if v := ParseStringP("a"); *v != "a" {
+		t.Errorf("Unexpected value %v", v)
+	}
+
+	var ne *strconv.NumError
+	if _, err := ParseInt8P("128"); !errors.As(err, &ne) || !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if _, err := ParseUintP("-1"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if _, err := ParseTimeP(time.RFC3339, "yesterday"); err == nil {
+		t.Errorf("Expected error for invalid time")
+	}
+}
+
+func TestFormatP(t *testing.T) {
+	cases := []struct {
+		out, expected string
+	}{
+		{FormatIntP(IntP(-3), "-"), "-3"},
+		{FormatIntP(nil, "-"), "-"},
+		{FormatInt64P(nil, `\N`), `\N`},
+		{FormatUint8P(Uint8P(255), ""), "255"},
+		{FormatFloat32P(Float32P(0.1), ""), "0.1"},
+		{FormatFloat64P(Float64P(1e21), ""), "1e+21"},
+		{FormatBoolP(FalseP(), "unset"), "false"},
+		{FormatDurationP(DurationP(time.Minute), ""), "1m0s"},
+		{FormatStringP(nil, "null"), "null"},
+		{FormatStringP(StringP(""), "null"), ""},
+		{FormatTimeP(TimeP(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)), time.DateOnly, ""), "2020-01-02"},
+		{FormatTimeP(nil, time.DateOnly, "never"), "never"},
+	}
+	for idx, c := range cases {
+		if c.out != c.expected {
+			t.Errorf("Unexpected value %q at idx %d", c.out, idx)
+		}
+	}
+}
+
+func TestParsePSlice(t *testing.T) {
+	out, err := ParseInt16PSlice([]string{"1", "", "-3"})
+	if err != nil || !reflect.DeepEqual([]*int16{Int16P(1), nil, Int16P(-3)}, out) {
+		t.Errorf("Unexpected value %v %v", out, err)
+	}
+	if _, err := ParseUint32PSlice([]string{"1", "x"}); err == nil || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Unexpected error %v", err)
+	} else if e, a := `index 1: strconv.ParseUint: parsing "x": invalid syntax`, err.Error(); e != a {
+		t.Errorf("Unexpected message %s", a)
+	}
+	if e, a := []*string{StringP("a"), nil}, ParseStringPSlice([]string{"a", ""}); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected value %v", a)
+	}
+	ts, err := ParseTimePSlice(time.RFC3339, []string{"", "2020-01-02T00:00:00Z"})
+	if err != nil || ts[0] != nil || ts[1].Year() != 2020 {
+		t.Errorf("Unexpected value %v %v", ts, err)
+	}
+}
@@ -0,0 +1,417 @@
+package pointer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// pathSegment is a single step of a compiled path: either a struct field
+// name or a bracketed slice index or map key.
+type pathSegment struct {
+	name    string
+	bracket bool
+	index   int // parsed index of a bracket segment, or -1
+}
+
+func (s pathSegment) String() string {
+	if s.bracket {
+		return "[" + s.name + "]"
+	}
+	return s.name
+}
+
+// maxCachedPaths bounds the number of compiled paths kept in pathCache.
+const maxCachedPaths = 1024
+
+var (
+	pathCacheMu sync.RWMutex
+	pathCache   = map[string][]pathSegment{}
+)
+
+// compilePath parses a dotted path such as `Spec.Containers[0].Env[HOME]`
+// into segments. Bracketed keys may be double-quoted to contain dots or
+// brackets. Compiled paths are cached by their full text; the cache is
+// emptied whenever it holds maxCachedPaths of them, so that paths built
+// from data cannot grow it without bound.
+func compilePath(path string) ([]pathSegment, error) {
+	pathCacheMu.RLock()
+	segs, ok := pathCache[path]
+	pathCacheMu.RUnlock()
+	if ok {
+		return segs, nil
+	}
+	for i := 0; i < len(path); {
+		switch c := path[i]; {
+		case c == '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("pointer: invalid path %q: unterminated [ at offset %d", path, i)
+			}
+			key := path[i+1 : i+end]
+			if strings.HasPrefix(key, `"`) {
+				n, err := quotedPrefix(path[i+1:])
+				if err != nil {
+					return nil, fmt.Errorf("pointer: invalid path %q: bad quoted key at offset %d", path, i)
+				}
+				if key, err = strconv.Unquote(path[i+1 : i+1+n]); err != nil || i+1+n >= len(path) || path[i+1+n] != ']' {
+					return nil, fmt.Errorf("pointer: invalid path %q: bad quoted key at offset %d", path, i)
+				}
+				end = n + 1
+			}
+			seg := pathSegment{name: key, bracket: true, index: -1}
+			if n, err := strconv.Atoi(key); err == nil && n >= 0 {
+				seg.index = n
+			}
+			segs = append(segs, seg)
+			i += end + 1
+		case c == '.' && len(segs) > 0 && i+1 < len(path) && path[i+1] != '.' && path[i+1] != '[':
+			i++
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("pointer: invalid path %q: empty segment at offset %d", path, i)
+			}
+			segs = append(segs, pathSegment{name: path[i : i+end], index: -1})
+			i += end
+		}
+	}
+	if len(segs) == 0 {
+		return nil, fmt.Errorf("pointer: invalid path %q: empty path", path)
+	}
+	pathCacheMu.Lock()
+	if len(pathCache) >= maxCachedPaths {
+		pathCache = make(map[string][]pathSegment, maxCachedPaths)
+	}
+	pathCache[path] = segs
+	pathCacheMu.Unlock()
+	return segs, nil
+}
+
+// quotedPrefix returns the length of the Go quoted string at the start of s.
+func quotedPrefix(s string) (int, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, strconv.ErrSyntax
+}
+
+// formatPath renders segs back into the dotted path syntax.
+func formatPath(segs []pathSegment) string {
+	var b strings.Builder
+	for i, s := range segs {
+		if i > 0 && !s.bracket {
+			b.WriteByte('.')
+		}
+		b.WriteString(s.String())
+	}
+	return b.String()
+}
+
+// pathField returns the field of struct type t named by seg.
+func pathField(t reflect.Type, seg pathSegment) (reflect.StructField, bool) {
+	if seg.bracket {
+		return reflect.StructField{}, false
+	}
+	sf, ok := t.FieldByName(seg.name)
+	if !ok || !sf.IsExported() {
+		return reflect.StructField{}, false
+	}
+	return sf, true
+}
+
+// pathMapKey converts the key of seg to the key type of map type t.
+func pathMapKey(t reflect.Type, seg pathSegment) (reflect.Value, error) {
+	if !seg.bracket {
+		return reflect.Value{}, fmt.Errorf("map %s requires a [key] segment", t)
+	}
+	return parseScalar(t.Key(), seg.name)
+}
+
+// Get follows the dotted path through root and returns the value it leads
+// to. Path segments name struct fields, with optional [index] segments for
+// slices and arrays and [key] segments for maps, e.g.
+//
+//	uid, ok := Get[int64](pod, "Spec.SecurityContext.RunAsUser")
+//	img, ok := Get[string](pod, `Spec.Containers[0].Image`)
+//	app, ok := Get[string](pod, `Labels["app.kubernetes.io/name"]`)
+//
+// Pointers and interfaces are followed along the way and the result is
+// dereferenced as far as needed to yield a T. ok is false if the path is
+// malformed, does not exist, crosses a nil link or does not lead to a T.
+func Get[T any](root any, path string) (T, bool) {
+	var zero T
+	segs, err := compilePath(path)
+	if err != nil {
+		return zero, false
+	}
+	v := reflect.ValueOf(root)
+	for _, seg := range segs {
+		if v = indirectValue(v); !v.IsValid() {
+			return zero, false
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			sf, ok := pathField(v.Type(), seg)
+			if !ok {
+				return zero, false
+			}
+			var err error
+			if v, err = fieldByIndex(v, sf.Index, false); err != nil {
+				return zero, false
+			}
+		case reflect.Slice, reflect.Array:
+			if seg.index < 0 || seg.index >= v.Len() {
+				return zero, false
+			}
+			v = v.Index(seg.index)
+		case reflect.Map:
+			key, err := pathMapKey(v.Type(), seg)
+			if err != nil {
+				return zero, false
+			}
+			if v = v.MapIndex(key); !v.IsValid() {
+				return zero, false
+			}
+		default:
+			return zero, false
+		}
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	levels := []reflect.Value{v}
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+		levels = append(levels, v)
+	}
+	if isNilValue(v) && v.Kind() != reflect.Map && v.Kind() != reflect.Slice {
+		return zero, false
+	}
+	for i := len(levels) - 1; i >= 0; i-- {
+		if levels[i].Type().AssignableTo(t) {
+			return levels[i].Interface().(T), true
+		}
+	}
+	return zero, false
+}
+
+// indirectValue follows pointers and interfaces from v, returning the
+// zero Value if a nil link is found.
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// Set follows the dotted path from root, which must be a non-nil pointer,
+// and assigns value to the field it leads to. Nil struct pointers and maps
+// along the way are allocated, and slices are grown to hold an indexed
+// element. If the target field is a *T and value is a T, a new pointer is
+// allocated, so
+//
+//	Set(&deploy, "Spec.Replicas", 3)
+//
+// allocates Spec if needed and sets Replicas to Int32P(3). Numeric values
+// are converted to the field's type if they fit, and a nil value clears
+// the field. Errors are reported as a *FieldError naming the path up to
+// the failing segment.
+func Set(root any, path string, value any) error {
+	segs, err := compilePath(path)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(root)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("pointer: Set requires a non-nil pointer, got %T", root)
+	}
+	return setPath(rv.Elem(), segs, 0, value)
+}
+
+func setPath(v reflect.Value, segs []pathSegment, i int, value any) error {
+	if i == len(segs) {
+		if err := assignValue(v, value); err != nil {
+			return &FieldError{Path: formatPath(segs), Err: err}
+		}
+		return nil
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	seg := segs[i]
+	fail := func(format string, args ...any) error {
+		return &FieldError{Path: formatPath(segs[:i+1]), Err: fmt.Errorf(format, args...)}
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return &FieldError{Path: formatPath(segs[:i]), Err: errors.New("cannot traverse nil interface")}
+		}
+		e := reflect.New(v.Elem().Type()).Elem()
+		e.Set(v.Elem())
+		if err := setPath(e, segs, i, value); err != nil {
+			return err
+		}
+		v.Set(e)
+		return nil
+	case reflect.Struct:
+		sf, ok := pathField(v.Type(), seg)
+		if !ok {
+			return fail("no field %s in %s", seg, v.Type())
+		}
+		f, err := fieldByIndex(v, sf.Index, true)
+		if err != nil {
+			return fail("%v", err)
+		}
+		return setPath(f, segs, i+1, value)
+	case reflect.Slice:
+		if seg.index < 0 {
+			return fail("invalid index %s for %s", seg, v.Type())
+		}
+		if seg.index >= v.Len() {
+			s := reflect.MakeSlice(v.Type(), seg.index+1, seg.index+1)
+			reflect.Copy(s, v)
+			v.Set(s)
+		}
+		return setPath(v.Index(seg.index), segs, i+1, value)
+	case reflect.Array:
+		if seg.index < 0 || seg.index >= v.Len() {
+			return fail("invalid index %s for %s", seg, v.Type())
+		}
+		return setPath(v.Index(seg.index), segs, i+1, value)
+	case reflect.Map:
+		key, err := pathMapKey(v.Type(), seg)
+		if err != nil {
+			return fail("%v", err)
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		e := reflect.New(v.Type().Elem()).Elem()
+		if cur := v.MapIndex(key); cur.IsValid() {
+			e.Set(cur)
+		}
+		if err := setPath(e, segs, i+1, value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, e)
+		return nil
+	}
+	return fail("cannot traverse %s", v.Type())
+}
+
+// assignValue stores value in v, allocating a pointer if v is a *T and
+// value a T, and converting numeric values that fit the target type.
+func assignValue(v reflect.Value, value any) error {
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(v.Type()) {
+		v.Set(rv)
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		e := reflect.New(v.Type().Elem())
+		if assignValue(e.Elem(), value) != nil {
+			return fmt.Errorf("cannot assign %s to %s", rv.Type(), v.Type())
+		}
+		v.Set(e)
+		return nil
+	}
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		return assignValue(v, rv.Elem().Interface())
+	}
+	if cv, ok := convertNumber(rv, v.Type()); ok {
+		v.Set(cv)
+		return nil
+	}
+	if rv.Kind() == v.Kind() && (rv.Kind() == reflect.String || rv.Kind() == reflect.Bool) {
+		v.Set(rv.Convert(v.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %s to %s", rv.Type(), v.Type())
+}
+
+// convertNumber converts the numeric value v to numeric type t, failing if
+// the value does not fit or would lose its fractional part.
+func convertNumber(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if !isNumberKind(v.Kind()) || !isNumberKind(t.Kind()) || t == durationType && v.Type() != durationType {
+		return reflect.Value{}, false
+	}
+	out := reflect.New(t).Elem()
+	switch {
+	case isIntKind(v.Kind()):
+		n := v.Int()
+		switch {
+		case isIntKind(t.Kind()):
+			if out.OverflowInt(n) {
+				return reflect.Value{}, false
+			}
+		case isUintKind(t.Kind()):
+			if n < 0 || out.OverflowUint(uint64(n)) {
+				return reflect.Value{}, false
+			}
+		}
+	case isUintKind(v.Kind()):
+		n := v.Uint()
+		switch {
+		case isIntKind(t.Kind()):
+			if n > 1<<63-1 || out.OverflowInt(int64(n)) {
+				return reflect.Value{}, false
+			}
+		case isUintKind(t.Kind()):
+			if out.OverflowUint(n) {
+				return reflect.Value{}, false
+			}
+		}
+	default:
+		f := v.Float()
+		switch {
+		case isIntKind(t.Kind()):
+			if f != float64(int64(f)) || out.OverflowInt(int64(f)) {
+				return reflect.Value{}, false
+			}
+		case isUintKind(t.Kind()):
+			if f < 0 || f != float64(uint64(f)) || out.OverflowUint(uint64(f)) {
+				return reflect.Value{}, false
+			}
+		default:
+			if out.OverflowFloat(f) {
+				return reflect.Value{}, false
+			}
+		}
+	}
+	return v.Convert(t), true
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || k == reflect.Float32 || k == reflect.Float64
+}
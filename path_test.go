@@ -0,0 +1,251 @@
+package pointer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type pathSecurity struct {
+	RunAsUser *int64
+}
+
+type pathContainer struct {
+	Name     string
+	Image    *string
+	Security *pathSecurity
+	Env      map[string]*string
+}
+
+type pathPodSpec struct {
+	Containers []pathContainer
+	Security   *pathSecurity
+	Started    *time.Time
+	ByPort     map[int32]*pathContainer
+	Extra      any
+}
+
+type pathPod struct {
+	Labels map[string]string
+	Spec   *pathPodSpec
+}
+
+func TestGet(t *testing.T) {
+	now := time.Now()
+	pod := &pathPod{
+		Labels: map[string]string{"app.kubernetes.io/name": "web", "tier": "front"},
+		Spec: &pathPodSpec{
+			Containers: []pathContainer{
+				{Name: "a", Image: StringP("nginx"), Security: &pathSecurity{RunAsUser: Int64P(1000)}, Env: map[string]*string{"HOME": StringP("/root"), "EMPTY": nil}},
+				{Name: "b"},
+			},
+			Started: &now,
+			ByPort:  map[int32]*pathContainer{80: {Name: "http"}},
+			Extra:   &pathSecurity{RunAsUser: Int64P(7)},
+		},
+	}
+
+	if v, ok := Get[int64](pod, "Spec.Containers[0].Security.RunAsUser"); !ok || v != 1000 {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if v, ok := Get[*int64](pod, "Spec.Containers[0].Security.RunAsUser"); !ok || *v != 1000 {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if v, ok := Get[string](*pod, `Labels["app.kubernetes.io/name"]`); !ok || v != "web" {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if v, ok := Get[string](pod, "Labels[tier]"); !ok || v != "front" {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if v, ok := Get[string](pod, "Spec.Containers[0].Env[HOME]"); !ok || v != "/root" {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if v, ok := Get[string](pod, "Spec.ByPort[80].Name"); !ok || v != "http" {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if v, ok := Get[time.Time](pod, "Spec.Started"); !ok || !v.Equal(now) {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if v, ok := Get[int64](pod, "Spec.Extra.RunAsUser"); !ok || v != 7 {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+	if v, ok := Get[any](pod, "Spec.Containers[1].Name"); !ok || v != "b" {
+		t.Errorf("Unexpected value %v %v", v, ok)
+	}
+
+	missing := []string{
+		"Spec.Security.RunAsUser",
+		"Spec.Containers[1].Security.RunAsUser",
+		"Spec.Containers[2].Name",
+		"Spec.Containers[0].Env[EMPTY]",
+		"Spec.Containers[0].Env[NOPE]",
+		"Spec.ByPort[x].Name",
+		"Spec.Nope",
+		"Spec.Containers.Name",
+		"Spec..Containers",
+		"Spec.Containers[0",
+		"",
+	}
+	for _, path := range missing {
+		if v, ok := Get[int64](pod, path); ok {
+			t.Errorf("Unexpected value %v for %q", v, path)
+		}
+	}
+	if v, ok := Get[int32](pod, "Spec.Containers[0].Security.RunAsUser"); ok {
+		t.Errorf("Unexpected value %v for mismatched type", v)
+	}
+	if _, ok := Get[string](nil, "Spec"); ok {
+		t.Errorf("Unexpected value for nil root")
+	}
+	if _, ok := Get[string]((*pathPod)(nil), "Spec"); ok {
+		t.Errorf("Unexpected value for typed nil root")
+	}
+}
+
+func TestCompilePath(t *testing.T) {
+	cases := map[string]string{
+		"A.B[0].C":         "A.B[0].C",
+		`A["x.y"].B`:       "A[x.y].B",
+		`A["a\"]b"][1][2]`: `A[a"]b][1][2]`,
+	}
+	for in, out := range cases {
+		segs, err := compilePath(in)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", in, err)
+			continue
+		}
+		if a := formatPath(segs); a != out {
+			t.Errorf("Unexpected path %q for %q", a, in)
+		}
+	}
+	for _, in := range []string{"", ".A", "A.", "A..B", "A[", `A["x]`, `A["x"y]`, "A.[0]"} {
+		if _, err := compilePath(in); err == nil {
+			t.Errorf("Expected error for %q", in)
+		}
+	}
+}
+
+func TestPathCacheBounded(t *testing.T) {
+	path := func(i int) string {
+		return fmt.Sprintf(`Items[%d].Labels["k%d"]`, i, i)
+	}
+	for i := 0; i < 3*maxCachedPaths; i++ {
+		if _, err := compilePath(path(i)); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		pathCacheMu.RLock()
+		_, ok := pathCache[path(i)]
+		n := len(pathCache)
+		pathCacheMu.RUnlock()
+		if !ok || n > maxCachedPaths {
+			t.Fatalf("Unexpected cache state for %s: cached %v, size %d", path(i), ok, n)
+		}
+	}
+	segs, err := compilePath(path(0))
+	if err != nil || len(segs) != 4 || segs[1].index != 0 || segs[3].name != "k0" {
+		t.Errorf("Unexpected result %v %v", segs, err)
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	pod := &pathPod{Spec: &pathPodSpec{Containers: []pathContainer{{Security: &pathSecurity{RunAsUser: Int64P(1)}}}}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Get[int64](pod, "Spec.Containers[0].Security.RunAsUser")
+	}
+}
+
+type pathDeploySpec struct {
+	Replicas *int32
+	Paused   bool
+	Timeout  *time.Duration
+	Pod      pathPodSpec
+}
+
+type pathDeploy struct {
+	Name   string
+	Spec   *pathDeploySpec
+	Labels map[string]*string
+	Ports  []*pathContainer
+	ByName map[string]pathContainer
+}
+
+func TestSet(t *testing.T) {
+	var d pathDeploy
+	sets := []struct {
+		path  string
+		value any
+	}{
+		{"Spec.Replicas", 3},
+		{"Spec.Paused", true},
+		{"Spec.Timeout", 5 * time.Second},
+		{"Spec.Pod.Containers[1].Image", "nginx"},
+		{"Spec.Pod.Security.RunAsUser", Int64P(1000)},
+		{`Labels["app.kubernetes.io/name"]`, "web"},
+		{"Ports[0].Name", "http"},
+		{"ByName[a].Env[HOME]", "/root"},
+		{"Name", "d"},
+	}
+	for _, s := range sets {
+		if err := Set(&d, s.path, s.value); err != nil {
+			t.Errorf("Unexpected error for %q: %v", s.path, err)
+		}
+	}
+	for _, s := range sets {
+		v, ok := Get[any](&d, s.path)
+		if !ok {
+			t.Errorf("Missing value for %q", s.path)
+			continue
+		}
+		expected := s.value
+		if p, ok := expected.(*int64); ok {
+			expected = *p
+		}
+		if s.path == "Spec.Replicas" {
+			expected = int32(3)
+		}
+		if expected != v {
+			t.Errorf("Unexpected value %v for %q", v, s.path)
+		}
+	}
+	if n := len(d.Spec.Pod.Containers); n != 2 {
+		t.Errorf("Unexpected len %d", n)
+	}
+
+	if err := Set(&d, "Spec.Replicas", nil); err != nil || d.Spec.Replicas != nil {
+		t.Errorf("Expected nil to clear field, got %v", err)
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	cases := map[string]struct {
+		value any
+		msg   string
+	}{
+		"Spec.Nope.X":          {1, "pointer: Spec.Nope: no field Nope in pointer.pathDeploySpec"},
+		"Spec.Replicas":        {1 << 40, "pointer: Spec.Replicas: cannot assign int to *int32"},
+		"Spec.Paused":          {"yes", "pointer: Spec.Paused: cannot assign string to bool"},
+		"Spec.Timeout":         {5, "pointer: Spec.Timeout: cannot assign int to *time.Duration"},
+		"Ports[x].Name":        {"a", "pointer: Ports[x]: invalid index [x] for []*pointer.pathContainer"},
+		"Spec.Pod.ByPort[x]":   {nil, `pointer: Spec.Pod.ByPort[x]: cannot parse "x" as int32: invalid syntax`},
+		"Spec.Pod.Extra.Image": {"a", "pointer: Spec.Pod.Extra: cannot traverse nil interface"},
+		"Name.Length":          {1, "pointer: Name.Length: cannot traverse string"},
+	}
+	for path, c := range cases {
+		var d pathDeploy
+		err := Set(&d, path, c.value)
+		if err == nil || err.Error() != c.msg {
+			t.Errorf("Unexpected error for %q: %v", path, err)
+		}
+	}
+	if err := Set(pathDeploy{}, "Name", "a"); err == nil {
+		t.Errorf("Expected error for non-pointer root")
+	}
+	if err := Set(&pathDeploy{}, "Name..", "a"); err == nil {
+		t.Errorf("Expected error for malformed path")
+	}
+	var o patchOuter
+	if err := Set(&o, "X", 1); err == nil || o.patchInner != nil {
+		t.Errorf("Expected error for unexported embedded pointer, got %v", err)
+	}
+}
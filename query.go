@@ -0,0 +1,269 @@
+package pointer
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// QueryOption configures DecodeQuery.
+type QueryOption func(*queryConfig)
+
+type queryConfig struct {
+	empty    EmptyPolicy
+	checkbox bool
+}
+
+// WithQueryEmptyPolicy sets how DecodeQuery treats parameters that are
+// present but empty, such as "?name=". The default is EmptyAsNil.
+func WithQueryEmptyPolicy(p EmptyPolicy) QueryOption {
+	return func(c *queryConfig) {
+		c.empty = p
+	}
+}
+
+// EncodeQuery encodes the fields of the struct v, or of the struct it
+// points to, as URL query parameters. A field is named by its
+// `query:"..."` tag, or else by its Go name; a `query:"-"` tag skips it.
+// Fields of nested structs are named parent.child.
+//
+// Nil pointers, slices and struct pointers are omitted. A slice field is
+// encoded as one parameter per element, with nil elements of a []*T
+// encoded as empty values, so that DecodeQuery restores them as nil.
+// Times are encoded in RFC 3339 format.
+func EncodeQuery(v any) (url.Values, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("pointer: EncodeQuery requires a struct, got %T", v)
+	}
+	values := url.Values{}
+	if err := encodeQueryStruct(values, rv, "", ""); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func encodeQueryStruct(values url.Values, v reflect.Value, prefix, path string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, ok := queryName(sf, prefix)
+		if !ok {
+			continue
+		}
+		f := v.Field(i)
+		fp := joinPath(path, sf.Name)
+		for f.Kind() == reflect.Ptr && !f.IsNil() && !isScalarType(f.Type()) {
+			f = f.Elem()
+		}
+		switch {
+		case isNilValue(f):
+			continue
+		case f.Kind() == reflect.Struct && !isScalarType(f.Type()):
+			if err := encodeQueryStruct(values, f, name, fp); err != nil {
+				return err
+			}
+			continue
+		case f.Kind() == reflect.Slice:
+			for j := 0; j < f.Len(); j++ {
+				s, err := formatQuery(f.Index(j))
+				if err != nil {
+					return &FieldError{Path: fmt.Sprintf("%s[%d]", fp, j), Err: err}
+				}
+				values.Add(name, s)
+			}
+			continue
+		}
+		s, err := formatQuery(f)
+		if err != nil {
+			return &FieldError{Path: fp, Err: err}
+		}
+		values.Add(name, s)
+	}
+	return nil
+}
+
+// formatQuery formats a scalar or a pointer to one, with nil as "".
+func formatQuery(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	return formatScalar(v)
+}
+
+// DecodeQuery fills the fields of the struct pointed to by v from URL
+// query parameters, named as for EncodeQuery. Absent parameters leave
+// their fields untouched, so pointer fields stay nil; nested struct
+// pointers are only allocated if one of their fields is set.
+//
+// A scalar field takes the first value of its parameter. A slice field
+// takes every value, so that repeated keys such as "?id=1&id=2" decode
+// into a []*T. Empty values follow the configured EmptyPolicy: for a
+// slice of pointers, EmptyAsNil decodes an empty value as a nil element,
+// and for other slices it drops the value.
+//
+// Every field that fails to decode is reported, as a FieldErrors of
+// *FieldError.
+func DecodeQuery(values url.Values, v any, opts ...QueryOption) error {
+	var cfg queryConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("pointer: DecodeQuery requires a non-nil pointer to a struct, got %T", v)
+	}
+	var errs FieldErrors
+	decodeQueryStruct(values, rv.Elem(), "", "", &cfg, &errs)
+	return errs.err()
+}
+
+func decodeQueryStruct(values url.Values, v reflect.Value, prefix, path string, cfg *queryConfig, errs *FieldErrors) bool {
+	set := false
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, ok := queryName(sf, prefix)
+		if !ok {
+			continue
+		}
+		f := v.Field(i)
+		fp := joinPath(path, sf.Name)
+
+		st := f.Type()
+		if st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		if st.Kind() == reflect.Struct && !isScalarType(st) {
+			// Only descend where a parameter is named below the field, so
+			// that the parameters bound the depth of recursive types.
+			if !hasQueryPrefix(values, name+".") {
+				continue
+			}
+			if f.Kind() == reflect.Struct {
+				set = decodeQueryStruct(values, f, name, fp, cfg, errs) || set
+				continue
+			}
+			target := f
+			if f.IsNil() {
+				target = reflect.New(st)
+			}
+			ok := decodeQueryStruct(values, target.Elem(), name, fp, cfg, errs)
+			if ok && f.IsNil() {
+				f.Set(target)
+			}
+			set = set || ok
+			continue
+		}
+
+		vs, ok := values[name]
+		if !ok || len(vs) == 0 {
+			continue
+		}
+		pv, ok, err := decodeQueryValue(f.Type(), vs, cfg)
+		if err != nil {
+			*errs = append(*errs, &FieldError{Path: fp, Err: fmt.Errorf("%s: %w", name, err)})
+			continue
+		}
+		if ok {
+			f.Set(pv)
+			set = true
+		}
+	}
+	return set
+}
+
+// hasQueryPrefix reports whether any parameter name starts with prefix.
+func hasQueryPrefix(values url.Values, prefix string) bool {
+	for name := range values {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeQueryValue decodes the values of one parameter into a value of
+// type t. It reports false if the values leave the field untouched.
+func decodeQueryValue(t reflect.Type, vs []string, cfg *queryConfig) (reflect.Value, bool, error) {
+	st := t
+	if st.Kind() == reflect.Ptr && st.Elem().Kind() == reflect.Slice {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Slice || isScalarType(st) {
+		return decodeQueryScalar(t, vs[0], cfg)
+	}
+	sv := reflect.MakeSlice(st, 0, len(vs))
+	for _, s := range vs {
+		e, ok, err := decodeQueryScalar(st.Elem(), s, cfg)
+		if err != nil {
+			return reflect.Value{}, false, err
+		}
+		switch {
+		case ok:
+			sv = reflect.Append(sv, e)
+		case st.Elem().Kind() == reflect.Ptr:
+			sv = reflect.Append(sv, reflect.Zero(st.Elem()))
+		}
+	}
+	if st == t {
+		return sv, true, nil
+	}
+	p := reflect.New(st)
+	p.Elem().Set(sv)
+	return p, true, nil
+}
+
+// decodeQueryScalar parses s as a scalar of type t or a pointer to one.
+func decodeQueryScalar(t reflect.Type, s string, cfg *queryConfig) (reflect.Value, bool, error) {
+	if s == "" {
+		switch cfg.empty {
+		case EmptyAsNil:
+			return reflect.Value{}, false, nil
+		case EmptyAsError:
+			return reflect.Value{}, false, errEmptyValue
+		}
+		return emptyValue(t), true, nil
+	}
+	et := t
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	if !isScalarType(et) {
+		return reflect.Value{}, false, fmt.Errorf("%w %s", errUnsupportedType, t)
+	}
+	if cfg.checkbox && et.Kind() == reflect.Bool && s == "on" {
+		s = "true"
+	}
+	pv, err := parseText(t, s)
+	return pv, err == nil, err
+}
+
+// queryName returns the parameter name of sf below prefix, or false if
+// the field is skipped.
+func queryName(sf reflect.StructField, prefix string) (string, bool) {
+	name := sf.Tag.Get("query")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		name = sf.Name
+	}
+	if prefix != "" {
+		name = prefix + "." + name
+	}
+	return name, true
+}
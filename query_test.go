@@ -0,0 +1,167 @@
+package pointer
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type queryPage struct {
+	Limit  *int `query:"limit"`
+	Cursor *string
+}
+
+type querySpec struct {
+	Name    *string  `query:"name"`
+	Active  *bool    `query:"active"`
+	Ratio   *float64 `query:"ratio"`
+	Wait    *time.Duration
+	Since   *time.Time `query:"since"`
+	IDs     []*int64   `query:"id"`
+	Tags    []string   `query:"tag"`
+	Page    *queryPage `query:"page"`
+	Count   int        `query:"count"`
+	Secret  *string    `query:"-"`
+	private *string
+}
+
+func TestEncodeQuery(t *testing.T) {
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	v := querySpec{
+		Name:  StringP("a b"),
+		Ratio: Float64P(0.5),
+		Wait:  DurationP(time.Minute),
+		Since: &since,
+		IDs:   []*int64{Int64P(1), nil, Int64P(3)},
+		Page:  &queryPage{Limit: IntP(10)},
+		Count: 2,
+	}
+	values, err := EncodeQuery(&v)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := url.Values{
+		"name":       {"a b"},
+		"ratio":      {"0.5"},
+		"Wait":       {"1m0s"},
+		"since":      {"2020-01-02T03:04:05Z"},
+		"id":         {"1", "", "3"},
+		"page.limit": {"10"},
+		"count":      {"2"},
+	}
+	if !reflect.DeepEqual(expected, values) {
+		t.Errorf("Unexpected value %v", values)
+	}
+
+	var out querySpec
+	if err := DecodeQuery(values, &out); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(v, out) {
+		t.Errorf("Unexpected round trip %+v", out)
+	}
+
+	if _, err := EncodeQuery(struct{ M map[string]int }{M: map[string]int{}}); err == nil {
+		t.Errorf("Expected error for map field")
+	}
+	if _, err := EncodeQuery(1); err == nil {
+		t.Errorf("Expected error for non-struct")
+	}
+}
+
+func TestDecodeQuery(t *testing.T) {
+	values, _ := url.ParseQuery("name=x&name=y&active=true&tag=a&tag=&tag=b&page.Cursor=c")
+	var out querySpec
+	if err := DecodeQuery(values, &out); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := querySpec{
+		Name:   StringP("x"),
+		Active: BoolP(true),
+		Tags:   []string{"a", "b"},
+		Page:   &queryPage{Cursor: StringP("c")},
+	}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Unexpected value %+v", out)
+	}
+
+	if err := DecodeQuery(url.Values{}, &out); err != nil || !reflect.DeepEqual(expected, out) {
+		t.Errorf("Unexpected change %+v %v", out, err)
+	}
+	if err := DecodeQuery(nil, out); err == nil {
+		t.Errorf("Expected error for non-pointer")
+	}
+}
+
+func TestDecodeQueryEmpty(t *testing.T) {
+	values := url.Values{"name": {""}, "id": {"1", ""}, "tag": {""}}
+
+	var nilOut querySpec
+	if err := DecodeQuery(values, &nilOut); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if nilOut.Name != nil || !reflect.DeepEqual([]*int64{Int64P(1), nil}, nilOut.IDs) || len(nilOut.Tags) != 0 {
+		t.Errorf("Unexpected value %+v", nilOut)
+	}
+
+	var zeroOut querySpec
+	if err := DecodeQuery(values, &zeroOut, WithQueryEmptyPolicy(EmptyAsZero)); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(StringP(""), zeroOut.Name) ||
+		!reflect.DeepEqual([]*int64{Int64P(1), Int64P(0)}, zeroOut.IDs) ||
+		!reflect.DeepEqual([]string{""}, zeroOut.Tags) {
+		t.Errorf("Unexpected value %+v", zeroOut)
+	}
+
+	err := DecodeQuery(values, &querySpec{}, WithQueryEmptyPolicy(EmptyAsError))
+	var fes FieldErrors
+	if !errors.As(err, &fes) || len(fes) != 3 || !errors.Is(fes[0], errEmptyValue) {
+		t.Errorf("Expected empty value errors, got %v", err)
+	}
+}
+
+func TestDecodeQueryErrors(t *testing.T) {
+	values := url.Values{"active": {"maybe"}, "id": {"1", "x"}, "page.limit": {"1.5"}}
+	err := DecodeQuery(values, &querySpec{})
+	var fes FieldErrors
+	if !errors.As(err, &fes) {
+		t.Fatalf("Expected FieldErrors, got %v", err)
+	}
+	paths := make([]string, len(fes))
+	for i, fe := range fes {
+		paths[i] = fe.Path
+	}
+	if e := []string{"Active", "IDs", "Page.Limit"}; !reflect.DeepEqual(e, paths) {
+		t.Errorf("Unexpected paths %v", paths)
+	}
+	if e, a := `pointer: Active: active: cannot parse "maybe" as bool: invalid syntax`, fes[0].Error(); e != a {
+		t.Errorf("Unexpected message %s", a)
+	}
+}
+
+type queryNode struct {
+	Name *string
+	Next *queryNode
+}
+
+func TestDecodeQueryRecursive(t *testing.T) {
+	n := queryNode{Name: StringP("a"), Next: &queryNode{Name: StringP("b"), Next: &queryNode{}}}
+	values, err := EncodeQuery(n)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if e := (url.Values{"Name": {"a"}, "Next.Name": {"b"}}); !reflect.DeepEqual(e, values) {
+		t.Errorf("Unexpected values %v", values)
+	}
+	var out queryNode
+	if err := DecodeQuery(values, &out); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	n.Next.Next = nil
+	if !reflect.DeepEqual(n, out) {
+		t.Errorf("Unexpected round trip %+v", out)
+	}
+}
@@ -0,0 +1,177 @@
+package pointer
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType              = reflect.TypeOf(time.Time{})
+	durationType          = reflect.TypeOf(time.Duration(0))
+	errUnsupportedType    = errors.New("unsupported type")
+	errUnsupportedMapType = errors.New("unsupported map key type")
+)
+
+// parseScalar parses s as a value of type t, which must be one of the
+// types covered by this package or implement encoding.TextUnmarshaler.
+// Times are parsed as RFC 3339 and durations by time.ParseDuration.
+func parseScalar(t reflect.Type, s string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch {
+	case t == timeType:
+		tm, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return v, fmt.Errorf("cannot parse %q as %s: expected RFC 3339", s, t)
+		}
+		v.Set(reflect.ValueOf(tm))
+		return v, nil
+	case t == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return v, fmt.Errorf("cannot parse %q as %s", s, t)
+		}
+		v.SetInt(int64(d))
+		return v, nil
+	case reflect.PtrTo(t).Implements(textUnmarshalerType):
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return v, fmt.Errorf("cannot parse %q as %s: %v", s, t, err)
+		}
+		return v, nil
+	}
+
+	var err error
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			v.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(s, 10, t.Bits()); err == nil {
+			v.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = strconv.ParseUint(s, 10, t.Bits()); err == nil {
+			v.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(s, t.Bits()); err == nil {
+			v.SetFloat(f)
+		}
+	default:
+		return v, fmt.Errorf("%w %s", errUnsupportedType, t)
+	}
+	if err != nil {
+		var ne *strconv.NumError
+		if errors.As(err, &ne) {
+			err = ne.Err
+		}
+		return v, fmt.Errorf("cannot parse %q as %s: %v", s, t, err)
+	}
+	return v, nil
+}
+
+// isScalarType reports whether parseScalar supports t.
+func isScalarType(t reflect.Type) bool {
+	if t == timeType || t == durationType || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseText parses s as a value of type t. Besides the scalar types,
+// pointers to them are allocated, slices are read as comma-separated
+// elements and string-keyed maps as comma-separated key=value pairs.
+func parseText(t reflect.Type, s string) (reflect.Value, error) {
+	switch {
+	case isScalarType(t):
+		return parseScalar(t, s)
+	case t.Kind() == reflect.Ptr:
+		e, err := parseText(t.Elem(), s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(e)
+		return p, nil
+	case t.Kind() == reflect.Slice:
+		var parts []string
+		if s != "" {
+			parts = strings.Split(s, ",")
+		}
+		v := reflect.MakeSlice(t, len(parts), len(parts))
+		for i, part := range parts {
+			e, err := parseText(t.Elem(), strings.TrimSpace(part))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v.Index(i).Set(e)
+		}
+		return v, nil
+	case t.Kind() == reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return reflect.Value{}, fmt.Errorf("%w %s", errUnsupportedMapType, t.Key())
+		}
+		v := reflect.MakeMap(t)
+		if s == "" {
+			return v, nil
+		}
+		for _, part := range strings.Split(s, ",") {
+			k, val, ok := strings.Cut(part, "=")
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("cannot parse %q as %s: expected key=value", part, t)
+			}
+			e, err := parseText(t.Elem(), strings.TrimSpace(val))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v.SetMapIndex(reflect.ValueOf(strings.TrimSpace(k)).Convert(t.Key()), e)
+		}
+		return v, nil
+	}
+	return reflect.Value{}, fmt.Errorf("%w %s", errUnsupportedType, t)
+}
+
+// formatScalar formats v, whose type must satisfy isScalarType, as text
+// that parseScalar reads back. Times are formatted as RFC 3339.
+func formatScalar(v reflect.Value) (string, error) {
+	switch {
+	case v.Type() == timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String(), nil
+	case v.Type().Implements(textMarshalerType):
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("%w %s", errUnsupportedType, v.Type())
+}
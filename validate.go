@@ -0,0 +1,173 @@
+package pointer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Violation describes a single validation rule that a field failed.
+type Violation struct {
+	Path    string // dotted Go field path
+	Rule    string // "required", "required_if" or "oneof"
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// ValidationError lists every violation found by Validate.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+	if len(msgs) == 1 {
+		return "pointer: validation failed: " + msgs[0]
+	}
+	return fmt.Sprintf("pointer: %d validation errors: %s", len(msgs), strings.Join(msgs, "; "))
+}
+
+// Validate walks the struct v and checks the rules given by `pointer`
+// tags on its fields:
+//
+//	required                the field must not be nil
+//	required_if=Field:value the field must not be nil if the sibling
+//	                        Field, dereferenced, formats as value
+//	oneof=group             exactly one field of the struct tagged with
+//	                        the same group must be non-nil
+//
+// Several rules may be combined, separated by commas. Nested structs are
+// walked through pointers, slices and maps. All violations are returned
+// together as a *ValidationError. A malformed tag is reported as a plain
+// error.
+func Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return errors.New("pointer: Validate requires a struct, got nil")
+	}
+	var vs []Violation
+	if err := validateValue(rv, "", &vs); err != nil {
+		return err
+	}
+	if len(vs) > 0 {
+		return &ValidationError{Violations: vs}
+	}
+	return nil
+}
+
+func validateValue(v reflect.Value, path string, vs *[]Violation) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			return validateValue(v.Elem(), path, vs)
+		}
+	case reflect.Struct:
+		if v.Type() != timeType {
+			return validateStruct(v, path, vs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), vs); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, k := range keys {
+			if err := validateValue(v.MapIndex(k), fmt.Sprintf("%s[%v]", path, k), vs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateStruct(v reflect.Value, path string, vs *[]Violation) error {
+	t := v.Type()
+	groups := map[string][]string{}
+	set := map[string][]string{}
+	var order []string
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		f := v.Field(i)
+		fp := joinPath(path, sf.Name)
+		if tag := sf.Tag.Get("pointer"); tag != "" {
+			for _, rule := range strings.Split(tag, ",") {
+				name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+				switch name {
+				case "required":
+					if isNilValue(f) {
+						*vs = append(*vs, Violation{Path: fp, Rule: name, Message: "is required"})
+					}
+				case "required_if":
+					other, want, ok := strings.Cut(arg, ":")
+					if !ok {
+						return fmt.Errorf("pointer: %s: malformed rule %q, expected required_if=Field:value", fp, rule)
+					}
+					osf, ok := t.FieldByName(other)
+					if !ok {
+						return fmt.Errorf("pointer: %s: rule %q refers to unknown field %s", fp, rule, other)
+					}
+					of, err := fieldByIndex(v, osf.Index, false)
+					if err != nil {
+						// The field is promoted through a nil embedded
+						// pointer, so it is not set.
+						break
+					}
+					for of.Kind() == reflect.Ptr && !of.IsNil() {
+						of = of.Elem()
+					}
+					if !isNilValue(of) && fmt.Sprint(of.Interface()) == want && isNilValue(f) {
+						*vs = append(*vs, Violation{Path: fp, Rule: name, Message: fmt.Sprintf("is required when %s is %s", other, want)})
+					}
+				case "oneof":
+					if arg == "" {
+						return fmt.Errorf("pointer: %s: malformed rule %q, expected oneof=group", fp, rule)
+					}
+					if _, ok := groups[arg]; !ok {
+						order = append(order, arg)
+					}
+					groups[arg] = append(groups[arg], sf.Name)
+					if !isNilValue(f) {
+						set[arg] = append(set[arg], sf.Name)
+					}
+				default:
+					return fmt.Errorf("pointer: %s: unknown rule %q", fp, rule)
+				}
+			}
+		}
+		if err := validateValue(f, fp, vs); err != nil {
+			return err
+		}
+	}
+	for _, g := range order {
+		switch n := len(set[g]); {
+		case n == 0:
+			*vs = append(*vs, Violation{Path: joinPath(path, g), Rule: "oneof",
+				Message: fmt.Sprintf("exactly one of %s must be set", strings.Join(groups[g], ", "))})
+		case n > 1:
+			*vs = append(*vs, Violation{Path: joinPath(path, g), Rule: "oneof",
+				Message: fmt.Sprintf("exactly one of %s must be set, got %s", strings.Join(groups[g], ", "), strings.Join(set[g], ", "))})
+		}
+	}
+	return nil
+}
+
+// isNilValue reports whether v is a nil pointer, interface, map or slice.
+func isNilValue(v reflect.Value) bool {
+	return isNilable(v.Kind()) && v.IsNil()
+}
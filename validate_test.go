@@ -0,0 +1,108 @@
+package pointer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type validateAuth struct {
+	Token    *string `pointer:"oneof=cred"`
+	Password *string `pointer:"oneof=cred"`
+	User     *string `pointer:"required_if=Password:set"`
+}
+
+type validateSpec struct {
+	Name    *string       `pointer:"required"`
+	Mode    *string       `pointer:"required"`
+	Workers *int          `pointer:"required_if=Mode:advanced"`
+	Auth    *validateAuth `pointer:"required"`
+	Items   []validateAuth
+	ByName  map[string]*validateAuth
+}
+
+func TestValidate(t *testing.T) {
+	ok := validateSpec{
+		Name: StringP("a"),
+		Mode: StringP("simple"),
+		Auth: &validateAuth{Token: StringP("t")},
+	}
+	if err := Validate(&ok); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if err := Validate(ok); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	bad := validateSpec{
+		Mode:   StringP("advanced"),
+		Items:  []validateAuth{{Token: StringP("t"), Password: StringP("set")}},
+		ByName: map[string]*validateAuth{"b": {}, "a": nil},
+	}
+	err := Validate(&bad)
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	expected := []Violation{
+		{Path: "Name", Rule: "required", Message: "is required"},
+		{Path: "Workers", Rule: "required_if", Message: "is required when Mode is advanced"},
+		{Path: "Auth", Rule: "required", Message: "is required"},
+		{Path: "Items[0].User", Rule: "required_if", Message: "is required when Password is set"},
+		{Path: "Items[0].cred", Rule: "oneof", Message: "exactly one of Token, Password must be set, got Token, Password"},
+		{Path: "ByName[b].cred", Rule: "oneof", Message: "exactly one of Token, Password must be set"},
+	}
+	if !reflect.DeepEqual(expected, ve.Violations) {
+		t.Errorf("Unexpected violations %v", ve.Violations)
+	}
+	if e, a := "pointer: validation failed: Name: is required", (&ValidationError{Violations: expected[:1]}).Error(); e != a {
+		t.Errorf("Unexpected message %s", a)
+	}
+}
+
+func TestValidateMalformed(t *testing.T) {
+	cases := []any{
+		struct {
+			A *int `pointer:"requird"`
+		}{},
+		struct {
+			A *int `pointer:"required_if=B"`
+		}{},
+		struct {
+			A *int `pointer:"required_if=B:x"`
+		}{},
+		struct {
+			A *int `pointer:"oneof"`
+		}{},
+	}
+	for idx, c := range cases {
+		err := Validate(c)
+		var ve *ValidationError
+		if err == nil || errors.As(err, &ve) {
+			t.Errorf("Expected tag error at idx %d, got %v", idx, err)
+		}
+	}
+	if err := Validate(nil); err == nil {
+		t.Errorf("Expected error for nil value")
+	}
+}
+
+type validateMode struct {
+	Mode *string
+}
+
+type validateEmbedded struct {
+	*validateMode
+	Workers *int `pointer:"required_if=Mode:advanced"`
+}
+
+func TestValidateEmbedded(t *testing.T) {
+	if err := Validate(validateEmbedded{}); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	err := Validate(validateEmbedded{validateMode: &validateMode{Mode: StringP("advanced")}})
+	var ve *ValidationError
+	if !errors.As(err, &ve) || len(ve.Violations) != 1 || ve.Violations[0].Path != "Workers" {
+		t.Errorf("Expected violation for Workers, got %v", err)
+	}
+}
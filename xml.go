@@ -0,0 +1,131 @@
+package pointer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+)
+
+// xsiNamespace is the XML Schema instance namespace of the xsi:nil
+// attribute.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// XMLOptional wraps a pointer for use as an XML element or attribute
+// field. A nil V is omitted on encoding, and an element or attribute that
+// is absent or marked xsi:nil="true" decodes as nil, so that nil survives
+// a round trip. T must be one of the types covered by this package, such
+// as string, bool, the sized integers and floats, time.Time (in RFC 3339
+// format) or time.Duration, or implement encoding.TextMarshaler and
+// encoding.TextUnmarshaler.
+type XMLOptional[T any] struct {
+	V *T
+}
+
+// XMLNillable is like XMLOptional, but encodes a nil V as an empty
+// element marked xsi:nil="true" instead of omitting it. As an attribute
+// it behaves like XMLOptional.
+type XMLNillable[T any] struct {
+	V *T
+}
+
+func (x XMLOptional[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if x.V == nil {
+		return nil
+	}
+	return marshalXMLElem(e, start, x.V)
+}
+
+func (x *XMLOptional[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLElem(d, start, &x.V)
+}
+
+func (x XMLOptional[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, x.V)
+}
+
+func (x *XMLOptional[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(attr, &x.V)
+}
+
+func (x XMLNillable[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if x.V == nil {
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+			xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"})
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+	return marshalXMLElem(e, start, x.V)
+}
+
+func (x *XMLNillable[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLElem(d, start, &x.V)
+}
+
+func (x XMLNillable[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, x.V)
+}
+
+func (x *XMLNillable[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(attr, &x.V)
+}
+
+func marshalXMLElem[T any](e *xml.Encoder, start xml.StartElement, p *T) error {
+	s, err := formatXML(p)
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(s, start)
+}
+
+func unmarshalXMLElem[T any](d *xml.Decoder, start xml.StartElement, p **T) error {
+	for _, a := range start.Attr {
+		if a.Name.Local == "nil" && (a.Name.Space == xsiNamespace || a.Name.Space == "xsi") && (a.Value == "true" || a.Value == "1") {
+			*p = nil
+			return d.Skip()
+		}
+	}
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return parseXML(s, p)
+}
+
+func marshalXMLAttr[T any](name xml.Name, p *T) (xml.Attr, error) {
+	if p == nil {
+		return xml.Attr{}, nil
+	}
+	s, err := formatXML(p)
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: s}, nil
+}
+
+func unmarshalXMLAttr[T any](attr xml.Attr, p **T) error {
+	return parseXML(attr.Value, p)
+}
+
+func formatXML[T any](p *T) (string, error) {
+	v := reflect.ValueOf(p).Elem()
+	if !isScalarType(v.Type()) {
+		return "", fmt.Errorf("pointer: %w %s", errUnsupportedType, v.Type())
+	}
+	return formatScalar(v)
+}
+
+func parseXML[T any](s string, p **T) error {
+	t := reflect.TypeOf(p).Elem().Elem()
+	if !isScalarType(t) {
+		return fmt.Errorf("pointer: %w %s", errUnsupportedType, t)
+	}
+	v, err := parseScalar(t, s)
+	if err != nil {
+		return fmt.Errorf("pointer: %v", err)
+	}
+	*p = v.Addr().Interface().(*T)
+	return nil
+}
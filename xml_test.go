@@ -0,0 +1,103 @@
+package pointer
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type xmlItem struct {
+	XMLName  xml.Name                   `xml:"item"`
+	ID       XMLOptional[int64]         `xml:"id,attr"`
+	Label    XMLOptional[string]        `xml:"label,attr"`
+	Name     XMLOptional[string]        `xml:"name"`
+	Active   XMLOptional[bool]          `xml:"active"`
+	Count    XMLOptional[uint8]         `xml:"count"`
+	Ratio    XMLOptional[float32]       `xml:"ratio"`
+	Wait     XMLOptional[time.Duration] `xml:"wait"`
+	Seen     XMLOptional[time.Time]     `xml:"seen"`
+	Parent   XMLNillable[int]           `xml:"parent"`
+	Comments []XMLNillable[string]      `xml:"comment"`
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	seen := time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+	cases := []struct {
+		in  xmlItem
+		out string
+	}{
+		{
+			in:  xmlItem{},
+			out: `<item><parent xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></parent></item>`,
+		},
+		{
+			in: xmlItem{
+				ID:       XMLOptional[int64]{Int64P(7)},
+				Label:    XMLOptional[string]{StringP("")},
+				Name:     XMLOptional[string]{StringP("a<b")},
+				Active:   XMLOptional[bool]{BoolP(false)},
+				Count:    XMLOptional[uint8]{Uint8P(0)},
+				Ratio:    XMLOptional[float32]{Float32P(0.25)},
+				Wait:     XMLOptional[time.Duration]{DurationP(time.Second)},
+				Seen:     XMLOptional[time.Time]{&seen},
+				Parent:   XMLNillable[int]{IntP(1)},
+				Comments: []XMLNillable[string]{{StringP("x")}, {}},
+			},
+			out: `<item id="7" label=""><name>a&lt;b</name><active>false</active><count>0</count>` +
+				`<ratio>0.25</ratio><wait>1s</wait><seen>2022-03-04T05:06:07Z</seen><parent>1</parent>` +
+				`<comment>x</comment><comment xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></comment></item>`,
+		},
+	}
+	for idx, c := range cases {
+		b, err := xml.Marshal(c.in)
+		if err != nil {
+			t.Fatalf("Unexpected error %v at idx %d", err, idx)
+		}
+		if e, a := c.out, string(b); e != a {
+			t.Errorf("Unexpected output at idx %d: %s", idx, a)
+		}
+		var out xmlItem
+		if err := xml.Unmarshal(b, &out); err != nil {
+			t.Fatalf("Unexpected error %v at idx %d", err, idx)
+		}
+		out.XMLName = xml.Name{}
+		if !reflect.DeepEqual(c.in, out) {
+			t.Errorf("Unexpected round trip at idx %d: %+v", idx, out)
+		}
+	}
+}
+
+func TestXMLDecode(t *testing.T) {
+	in := `<item xmlns:x="http://www.w3.org/2001/XMLSchema-instance">` +
+		`<name x:nil="true"/><active xsi:nil="1"/><count>3</count></item>`
+	out := xmlItem{Name: XMLOptional[string]{StringP("old")}}
+	if err := xml.Unmarshal([]byte(in), &out); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if out.Name.V != nil || out.Active.V != nil || !reflect.DeepEqual(Uint8P(3), out.Count.V) {
+		t.Errorf("Unexpected value %+v", out)
+	}
+
+	for idx, bad := range []string{
+		`<item><count>300</count></item>`,
+		`<item id="x"></item>`,
+		`<item><seen>yesterday</seen></item>`,
+	} {
+		if err := xml.Unmarshal([]byte(bad), &xmlItem{}); err == nil {
+			t.Errorf("Expected error at idx %d", idx)
+		}
+	}
+
+	var m struct {
+		M XMLOptional[map[string]int] `xml:"m"`
+	}
+	if _, err := xml.Marshal(struct {
+		M XMLOptional[[]int] `xml:"m"`
+	}{XMLOptional[[]int]{&[]int{1}}}); err == nil {
+		t.Errorf("Expected error for unsupported type")
+	}
+	if err := xml.Unmarshal([]byte(`<x><m>1</m></x>`), &m); err == nil {
+		t.Errorf("Expected error for unsupported type")
+	}
+}